@@ -1,12 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"runtime"
-	"strings"
 
 	"github.com/spf13/cobra"
-	"littleriver.cc/go-nano/nano"
 	"littleriver.cc/go-nano/nano/wallet"
 )
 
@@ -21,12 +20,6 @@ var (
 	threads int
 )
 
-type result struct {
-	Seed       *wallet.Seed
-	Address    nano.Address
-	Iterations uint64
-}
-
 func init() {
 	rootCmd.Flags().StringVar(&prefix, "prefix", "", "prefix to search for")
 	rootCmd.Flags().IntVar(&threads, "threads", runtime.NumCPU(), "number of threads to use")
@@ -40,44 +33,15 @@ func main() {
 }
 
 func startVanity(cmd *cobra.Command, args []string) {
-	for _, c := range prefix {
-		if !strings.ContainsRune(nano.AddressEncodingAlphabet, c) {
-			fmt.Printf("error: char '%c' is not in nano's encoding alphabet\n", c)
-			return
-		}
-	}
-
-	c := make(chan *result)
-	for n := 0; n < threads; n++ {
-		go findAddress(prefix, c)
-	}
-
 	fmt.Printf("searching for address with prefix '%s' on %d threads\n", prefix, threads)
 
-	if res := <-c; res != nil {
-		fmt.Printf("found a match! (after %d iterations)\n", res.Iterations)
-		fmt.Printf("seed: %s\n", res.Seed)
-		fmt.Printf("address: %s\n", res.Address)
-	} else {
-		fmt.Printf("no match found!\n")
+	seed, address, err := wallet.SearchVanity(context.Background(), prefix, threads)
+	if err != nil {
+		fmt.Printf("error: %s\n", err)
+		return
 	}
-}
-
-func findAddress(prefix string, c chan *result) {
-	for i := uint64(0); ; i++ {
-		seed, err := wallet.GenerateSeed()
-		if err != nil {
-			panic(err)
-		}
 
-		key, err := seed.Key(0)
-		if err != nil {
-			panic(err)
-		}
-
-		addr := wallet.NewAccount(key).Address()
-		if strings.HasPrefix(addr.String()[len(nano.AddressPrefix)+1:], prefix) {
-			c <- &result{seed, addr, i}
-		}
-	}
+	fmt.Printf("found a match!\n")
+	fmt.Printf("seed: %s\n", seed)
+	fmt.Printf("address: %s\n", address)
 }
@@ -6,7 +6,7 @@ import (
 	"errors"
 	"strings"
 
-	"golang.org/x/crypto/blake2b"
+	"littleriver.cc/go-nano/nano/crypto"
 	"littleriver.cc/go-nano/nano/crypto/ed25519"
 	"littleriver.cc/go-nano/nano/internal/util"
 )
@@ -23,8 +23,13 @@ const (
 	// AddressPrefixOld is the old prefix of Nano addresses.
 	AddressPrefixOld = "xrb_"
 
+	// AddressChecksumSize is the size of the checksum of an address in bytes.
+	AddressChecksumSize = 5
+
 	// AddressEncodingAlphabet is Nano's custom alphabet for base32 encoding
 	AddressEncodingAlphabet = "13456789abcdefghijkmnopqrstuwxyz"
+
+	addressEncodedLen = AddressLen - len(AddressPrefixOld)
 )
 
 var (
@@ -36,8 +41,73 @@ var (
 	ErrAddressPrefix   = errors.New("bad address prefix")
 	ErrAddressEncoding = errors.New("bad address encoding")
 	ErrAddressChecksum = errors.New("bad address checksum")
+	ErrAddressKeySize  = errors.New("bad address public key size")
+	ErrNanoBase32      = errors.New("bad nano base32 encoding")
 )
 
+// EncodeNanoBase32 encodes the given data with AddressEncodingAlphabet the way
+// Nano encodes keys and checksums in addresses: the data is read as a big
+// endian bit string, left padded with zero bits to a multiple of 5 bits, and
+// written without padding characters. A 32 byte key encodes to 52 characters.
+func EncodeNanoBase32(data []byte) string {
+	n := (len(data)*8 + 4) / 5
+	out := make([]byte, n)
+
+	// fill the output from the end so that the padding ends up in front
+	var buf uint
+	var bits uint
+	i := n
+	for j := len(data) - 1; j >= 0; j-- {
+		buf |= uint(data[j]) << bits
+		bits += 8
+		for bits >= 5 {
+			i--
+			out[i] = AddressEncodingAlphabet[buf&0x1f]
+			buf >>= 5
+			bits -= 5
+		}
+	}
+	if bits > 0 {
+		i--
+		out[i] = AddressEncodingAlphabet[buf&0x1f]
+	}
+	return string(out)
+}
+
+// DecodeNanoBase32 decodes a string encoded by EncodeNanoBase32. It returns
+// ErrNanoBase32 if the string contains characters outside of
+// AddressEncodingAlphabet, if its length doesn't match a whole number of
+// bytes, or if the padding bits are not zero.
+func DecodeNanoBase32(s string) ([]byte, error) {
+	size := len(s) * 5 / 8
+	if (size*8+4)/5 != len(s) {
+		return nil, ErrNanoBase32
+	}
+	out := make([]byte, size)
+
+	var buf uint
+	var bits uint
+	i := size
+	for j := len(s) - 1; j >= 0; j-- {
+		v := strings.IndexByte(AddressEncodingAlphabet, s[j])
+		if v < 0 {
+			return nil, ErrNanoBase32
+		}
+		buf |= uint(v) << bits
+		bits += 5
+		if bits >= 8 && i > 0 {
+			i--
+			out[i] = byte(buf)
+			buf >>= 8
+			bits -= 8
+		}
+	}
+	if buf != 0 {
+		return nil, ErrNanoBase32
+	}
+	return out, nil
+}
+
 // Address represents a Nano address.
 type Address [AddressSize]byte
 
@@ -48,25 +118,32 @@ func ParseAddress(s string) (Address, error) {
 	}
 
 	if strings.HasPrefix(s, AddressPrefix) {
-		s = s[5:]
+		s = s[len(AddressPrefix):]
 	} else if strings.HasPrefix(s, AddressPrefixOld) {
-		s = s[4:]
+		s = s[len(AddressPrefixOld):]
 	} else {
 		return Address{}, ErrAddressPrefix
 	}
 
-	key, err := AddressEncoding.DecodeString("1111" + s[:52])
+	// the length of the encoded key and checksum doesn't depend on the prefix
+	if len(s) != addressEncodedLen {
+		return Address{}, ErrAddressLen
+	}
+
+	// the encoded key has 4 bits of padding, which must all be zero. This
+	// means the first character is either 1 or 3.
+	key, err := DecodeNanoBase32(s[:52])
 	if err != nil {
 		return Address{}, ErrAddressEncoding
 	}
 
-	checksum, err := AddressEncoding.DecodeString(s[52:])
+	checksum, err := DecodeNanoBase32(s[52:])
 	if err != nil {
 		return Address{}, ErrAddressEncoding
 	}
 
 	var address Address
-	copy(address[:], key[3:])
+	copy(address[:], key)
 
 	if !bytes.Equal(address.Checksum(), checksum) {
 		return Address{}, ErrAddressChecksum
@@ -75,33 +152,124 @@ func ParseAddress(s string) (Address, error) {
 	return address, nil
 }
 
-// Checksum calculates the checksum for this address' public key.
-func (a Address) Checksum() []byte {
-	hash, err := blake2b.New(5, nil)
+// IsValidAddress reports whether the given string is a valid Nano address with
+// either the nano_ or the xrb_ prefix.
+func IsValidAddress(s string) bool {
+	_, err := ParseAddress(s)
+	return err == nil
+}
+
+// ValidateAddresses validates each of the given addresses. The returned slice
+// has the same length as the given one, with a nil entry for every valid
+// address.
+func ValidateAddresses(list []string) []error {
+	errs := make([]error, len(list))
+	for i, s := range list {
+		_, errs[i] = ParseAddress(s)
+	}
+	return errs
+}
+
+// NormalizeAddress validates the given address and returns it with the nano_
+// prefix.
+func NormalizeAddress(s string) (string, error) {
+	address, err := ParseAddress(s)
+	if err != nil {
+		return "", err
+	}
+
+	return address.String(), nil
+}
+
+// EncodeAddress returns the address of the given public key. It panics if the
+// key does not have the size of an address, use AddressFromPublicKey to get an
+// error instead.
+func EncodeAddress(key ed25519.PublicKey) Address {
+	address, err := AddressFromPublicKey(key)
 	if err != nil {
 		panic(err)
 	}
+	return address
+}
 
-	hash.Write(a[:])
-	return util.ReverseBytes(hash.Sum(nil))
+// AddressFromPublicKey returns the address of the given public key.
+func AddressFromPublicKey(key ed25519.PublicKey) (Address, error) {
+	if len(key) != AddressSize {
+		return Address{}, ErrAddressKeySize
+	}
+
+	var address Address
+	copy(address[:], key)
+	return address, nil
 }
 
-// String implements the fmt.Stringer interface.
+// AddressFromPublicKeyHex returns the address of the given hex encoded public
+// key.
+func AddressFromPublicKeyHex(s string) (Address, error) {
+	key, err := ed25519.ParsePublicKeyHex(s)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return AddressFromPublicKey(key)
+}
+
+// AddressChecksum returns the checksum of the given public key, as encoded in
+// the last 8 characters of its address. The checksum is the blake2b digest of
+// the key with a size of 5 bytes, in reverse byte order: the last byte of the
+// digest comes first.
+func AddressChecksum(pub ed25519.PublicKey) [AddressChecksumSize]byte {
+	var checksum [AddressChecksumSize]byte
+	copy(checksum[:], util.ReverseBytes(crypto.Hash(AddressChecksumSize, pub)))
+	return checksum
+}
+
+// Checksum calculates the checksum for this address' public key, as returned by
+// AddressChecksum.
+func (a Address) Checksum() []byte {
+	checksum := AddressChecksum(a[:])
+	return checksum[:]
+}
+
+// String implements the fmt.Stringer interface. It returns the address with
+// the nano_ prefix.
 func (a Address) String() string {
-	key := append([]byte{0, 0, 0}, a[:]...)
-	encodedKey := AddressEncoding.EncodeToString(key)[4:]
-	encodedChecksum := AddressEncoding.EncodeToString(a.Checksum())
+	return a.encode(AddressPrefix)
+}
+
+// LegacyString returns the address with the old xrb_ prefix.
+func (a Address) LegacyString() string {
+	return a.encode(AddressPrefixOld)
+}
+
+func (a Address) encode(prefix string) string {
+	encodedKey := EncodeNanoBase32(a[:])
+	encodedChecksum := EncodeNanoBase32(a.Checksum())
 
 	var buf bytes.Buffer
-	buf.WriteString(AddressPrefix)
+	buf.WriteString(prefix)
 	buf.WriteString(encodedKey)
 	buf.WriteString(encodedChecksum)
 	return buf.String()
 }
 
+// PublicKey returns the public key this address represents. The checksum is
+// verified when an address is parsed, so there is nothing left to check here.
+func (a Address) PublicKey() ed25519.PublicKey {
+	key := make(ed25519.PublicKey, AddressSize)
+	copy(key, a[:])
+	return key
+}
+
+// PublicKeyHex returns the hex representation of the public key this address
+// represents.
+func (a Address) PublicKeyHex() string {
+	return a.PublicKey().Hex()
+}
+
 // Verify reports whether the given signature is valid for the given data.
 func (a Address) Verify(data []byte, signature []byte) bool {
-	return ed25519.Verify(ed25519.PublicKey(a[:]), data, signature)
+	return ed25519.Verify(a.PublicKey(), data, signature)
 }
 
 // MarshalText implements the encoding.TextMarshaler interface.
@@ -2,11 +2,21 @@ package nano
 
 import (
 	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"strings"
 	"testing"
 
+	"littleriver.cc/go-nano/nano/crypto/ed25519"
 	"littleriver.cc/go-nano/nano/internal/util"
 )
 
+const (
+	genesisAddress = "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3"
+	genesisKey     = "e89208dd038fbb269987689621d52292ae9c35941a7484756ecced92a65093ba"
+)
+
 func TestNanoAddress(t *testing.T) {
 	s1 := "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3"
 	s2 := "nano_1111111111111111111111111111111111111111111111111111hifc8npp"
@@ -30,3 +40,248 @@ func TestNanoAddress(t *testing.T) {
 		t.Fatalf("address is not zero")
 	}
 }
+
+func TestAddressChecksum(t *testing.T) {
+	key := ed25519.PublicKey(util.MustDecodeHex(genesisKey))
+	expected := [AddressChecksumSize]byte{0xad, 0x15, 0xba, 0xbf, 0x01}
+
+	if checksum := AddressChecksum(key); checksum != expected {
+		t.Fatalf("expected: %x, got: %x", expected, checksum)
+	}
+
+	address := EncodeAddress(key)
+	if checksum := address.Checksum(); !bytes.Equal(checksum, expected[:]) {
+		t.Fatalf("expected: %x, got: %x", expected, checksum)
+	}
+
+	encoded := AddressEncoding.EncodeToString(expected[:])
+	if !strings.HasSuffix(genesisAddress, encoded) {
+		t.Fatalf("expected %s to end with %s", genesisAddress, encoded)
+	}
+}
+
+func TestNanoAddressGenesis(t *testing.T) {
+	key := ed25519.PublicKey(util.MustDecodeHex(genesisKey))
+	address, err := AddressFromPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if address.String() != genesisAddress {
+		t.Fatalf("expected: %s, got: %s", genesisAddress, address)
+	}
+
+	for _, s := range []string{genesisAddress, "xrb_" + genesisAddress[len(AddressPrefix):]} {
+		parsed, err := ParseAddress(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != address {
+			t.Fatalf("(%s) expected: %s, got: %s", s, address, parsed)
+		}
+	}
+
+	if _, err = AddressFromPublicKey(key[1:]); err != ErrAddressKeySize {
+		t.Fatalf("expected bad key size error, got: %v", err)
+	}
+
+	if encoded := EncodeAddress(key); encoded.String() != genesisAddress {
+		t.Fatalf("expected: %s, got: %s", genesisAddress, encoded)
+	}
+}
+
+func TestNanoAddressInvalid(t *testing.T) {
+	body := genesisAddress[len(AddressPrefix):]
+	tests := map[string]error{
+		"nano_" + body[:59] + "1": ErrAddressChecksum,
+		"nano_" + body[:59]:       ErrAddressLen,
+		"xrb_" + body + "3":       ErrAddressLen,
+		"nano_" + body[:59] + "0": ErrAddressEncoding,
+		"nono_" + body:            ErrAddressPrefix,
+		"":                        ErrAddressLen,
+	}
+
+	for s, expected := range tests {
+		if _, err := ParseAddress(s); err != expected {
+			t.Errorf("(%s) expected: %v, got: %v", s, expected, err)
+		}
+	}
+}
+
+func TestNanoAddressIsValid(t *testing.T) {
+	body := genesisAddress[len(AddressPrefix):]
+	tests := map[string]bool{
+		genesisAddress:            true,
+		"xrb_" + body:             true,
+		"nano_" + body[:59] + "1": false,
+		"nano_" + body[:59]:       false,
+		"nano_" + body + "1":      false,
+		"nano_" + body[:59] + "l": false,
+		"xrb" + body:              false,
+		"nano_5" + body[1:]:       false,
+		"nano_7" + body[1:]:       false,
+		"nano_z" + body[1:]:       false,
+		"xrb_z" + body[1:]:        false,
+	}
+
+	for s, valid := range tests {
+		if IsValidAddress(s) != valid {
+			t.Errorf("(%s) expected valid to be %t", s, valid)
+		}
+	}
+}
+
+func TestNanoAddressPrefix(t *testing.T) {
+	legacy := "xrb_" + genesisAddress[len(AddressPrefix):]
+
+	for _, s := range []string{genesisAddress, legacy} {
+		normalized, err := NormalizeAddress(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if normalized != genesisAddress {
+			t.Errorf("expected: %s, got: %s", genesisAddress, normalized)
+		}
+	}
+
+	address, err := ParseAddress(genesisAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address.LegacyString() != legacy {
+		t.Errorf("expected: %s, got: %s", legacy, address.LegacyString())
+	}
+	if !IsValidAddress(address.LegacyString()) {
+		t.Errorf("legacy address is not valid")
+	}
+
+	if _, err = NormalizeAddress(legacy[:len(legacy)-1] + "1"); err != ErrAddressChecksum {
+		t.Errorf("expected checksum error, got: %v", err)
+	}
+	if _, err = NormalizeAddress("xrb_z" + legacy[5:]); err != ErrAddressEncoding {
+		t.Errorf("expected encoding error, got: %v", err)
+	}
+}
+
+func TestNanoAddressPublicKey(t *testing.T) {
+	address, err := ParseAddress(genesisAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := address.PublicKey()
+	if !bytes.Equal(key, util.MustDecodeHex(genesisKey)) {
+		t.Fatalf("unexpected public key: %x", key)
+	}
+
+	// the returned key must not alias the address
+	key[0] = 0
+	if address.PublicKey()[0] == 0 {
+		t.Fatalf("public key aliases the address")
+	}
+}
+
+func TestNanoAddressValidateList(t *testing.T) {
+	body := genesisAddress[len(AddressPrefix):]
+	list := []string{
+		genesisAddress,
+		"nano_" + body[:59] + "1",
+		"xrb_" + body,
+		"not an address",
+		"",
+	}
+	expected := []error{nil, ErrAddressChecksum, nil, ErrAddressLen, ErrAddressLen}
+
+	errs := ValidateAddresses(list)
+	if len(errs) != len(list) {
+		t.Fatalf("expected %d results, got: %d", len(list), len(errs))
+	}
+	for i := range errs {
+		if errs[i] != expected[i] {
+			t.Errorf("(%d) expected: %v, got: %v", i, expected[i], errs[i])
+		}
+	}
+}
+
+func TestNanoAddressPublicKeyHex(t *testing.T) {
+	address, err := AddressFromPublicKeyHex(strings.ToUpper(genesisKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address.String() != genesisAddress {
+		t.Fatalf("expected: %s, got: %s", genesisAddress, address)
+	}
+
+	if address, err = ParseAddress(genesisAddress); err != nil {
+		t.Fatal(err)
+	}
+	if address.PublicKeyHex() != genesisKey {
+		t.Fatalf("expected: %s, got: %s", genesisKey, address.PublicKeyHex())
+	}
+
+	if _, err = AddressFromPublicKeyHex(genesisKey[2:]); err != ed25519.ErrPublicKeyHexLen {
+		t.Errorf("expected bad length error, got: %v", err)
+	}
+
+	var hexErr hex.InvalidByteError
+	if _, err = AddressFromPublicKeyHex(genesisKey[1:] + "z"); !errors.As(err, &hexErr) {
+		t.Errorf("expected bad hex error, got: %v", err)
+	}
+}
+
+func TestNanoBase32RoundTrip(t *testing.T) {
+	genesis := util.MustDecodeHex(genesisKey)
+	if encoded := EncodeNanoBase32(genesis); encoded != genesisAddress[len(AddressPrefix):len(AddressPrefix)+52] {
+		t.Fatalf("expected: %s, got: %s", genesisAddress[len(AddressPrefix):len(AddressPrefix)+52], encoded)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		key := make([]byte, AddressSize)
+		r.Read(key)
+
+		encoded := EncodeNanoBase32(key)
+		if len(encoded) != 52 {
+			t.Fatalf("expected 52 characters, got: %d", len(encoded))
+		}
+		decoded, err := DecodeNanoBase32(encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(decoded, key) {
+			t.Fatalf("expected: %x, got: %x", key, decoded)
+		}
+	}
+
+	for size := 0; size <= 10; size++ {
+		data := bytes.Repeat([]byte{0xff}, size)
+		decoded, err := DecodeNanoBase32(EncodeNanoBase32(data))
+		if err != nil {
+			t.Fatalf("(%d bytes) %v", size, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Fatalf("(%d bytes) expected: %x, got: %x", size, data, decoded)
+		}
+	}
+}
+
+func TestNanoBase32Invalid(t *testing.T) {
+	valid := EncodeNanoBase32(util.MustDecodeHex(genesisKey))
+
+	for _, c := range []string{"0", "2", "l", "v", "A", "_"} {
+		s := valid[:10] + c + valid[11:]
+		if _, err := DecodeNanoBase32(s); !errors.Is(err, ErrNanoBase32) {
+			t.Fatalf("(%s) expected bad encoding error, got: %v", c, err)
+		}
+	}
+
+	// 3 characters can't hold a whole number of bytes without extra padding
+	if _, err := DecodeNanoBase32("111"); !errors.Is(err, ErrNanoBase32) {
+		t.Fatalf("expected bad encoding error for length, got: %v", err)
+	}
+
+	// the leading 4 padding bits of a key must be zero
+	if _, err := DecodeNanoBase32("5" + valid[1:]); !errors.Is(err, ErrNanoBase32) {
+		t.Fatalf("expected bad encoding error for padding, got: %v", err)
+	}
+}
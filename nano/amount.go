@@ -0,0 +1,129 @@
+package nano
+
+import "encoding/json"
+
+// SignedAmount is a balance with a sign, like a debit or a credit. The zero
+// value is zero.
+type SignedAmount struct {
+	abs Balance
+	neg bool
+}
+
+// NewSignedAmount returns the signed amount with the given magnitude, which is
+// negative if negative is set and the magnitude is not zero.
+func NewSignedAmount(magnitude Balance, negative bool) SignedAmount {
+	return SignedAmount{abs: magnitude, neg: negative && !magnitude.IsZero()}
+}
+
+// SignedAmountFromBalance returns the given balance as a positive amount.
+func SignedAmountFromBalance(b Balance) SignedAmount {
+	return SignedAmount{abs: b}
+}
+
+// ParseSignedAmount parses the given raw integer string with an optional
+// leading minus sign. Unlike ParseBalance, it only accepts digits.
+func ParseSignedAmount(s string) (SignedAmount, error) {
+	negative := len(s) > 0 && s[0] == '-'
+	if negative {
+		s = s[1:]
+	}
+	if !isRawInteger(s) {
+		return SignedAmount{}, ErrBadBalanceFormat
+	}
+
+	b, err := ParseBalance(s, "raw")
+	if err != nil {
+		return SignedAmount{}, err
+	}
+	return NewSignedAmount(b, negative), nil
+}
+
+// Abs returns the magnitude of this amount.
+func (a SignedAmount) Abs() Balance {
+	return a.abs
+}
+
+// IsNegative reports whether this amount is smaller than zero.
+func (a SignedAmount) IsNegative() bool {
+	return a.neg
+}
+
+// Neg returns this amount with the opposite sign.
+func (a SignedAmount) Neg() SignedAmount {
+	return NewSignedAmount(a.abs, !a.neg)
+}
+
+// Add returns the sum of this amount and the given amount. It returns
+// ErrBalanceOverflow if the magnitude of the sum does not fit in a balance.
+func (a SignedAmount) Add(n SignedAmount) (SignedAmount, error) {
+	if a.neg == n.neg {
+		abs, err := a.abs.CheckedAdd(n.abs)
+		if err != nil {
+			return SignedAmount{}, err
+		}
+		return NewSignedAmount(abs, a.neg), nil
+	}
+
+	// the signs differ, so the result takes the sign of the larger magnitude
+	abs, increased := BalanceDelta(n.abs, a.abs)
+	if increased {
+		return NewSignedAmount(abs, a.neg), nil
+	}
+	return NewSignedAmount(abs, n.neg), nil
+}
+
+// Sub returns the difference between this amount and the given amount. It
+// returns ErrBalanceOverflow if the magnitude of the difference does not fit in
+// a balance.
+func (a SignedAmount) Sub(n SignedAmount) (SignedAmount, error) {
+	return a.Add(n.Neg())
+}
+
+// Balance returns this amount as a balance. It returns ErrNegativeBalance if the
+// amount is negative.
+func (a SignedAmount) Balance() (Balance, error) {
+	if a.neg {
+		return ZeroBalance, ErrNegativeBalance
+	}
+	return a.abs, nil
+}
+
+// String implements the fmt.Stringer interface. It returns the amount as a raw
+// integer string with a leading minus sign if it is negative.
+func (a SignedAmount) String() string {
+	if a.neg {
+		return "-" + a.abs.RawString()
+	}
+	return a.abs.RawString()
+}
+
+// MarshalJSON implements the json.Marshaler interface. It encodes the amount as
+// a signed raw integer string.
+func (a SignedAmount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Like
+// Balance.UnmarshalJSON, it returns ErrBalanceMustBeString for JSON numbers
+// and leaves the amount unchanged for null.
+func (a *SignedAmount) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) == 0 || data[0] != '"' {
+		return ErrBalanceMustBeString
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	amount, err := ParseSignedAmount(s)
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
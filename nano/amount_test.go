@@ -0,0 +1,108 @@
+package nano
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNanoSignedAmount(t *testing.T) {
+	credit := SignedAmountFromBalance(ParseBalanceInts(0, 100))
+	debit := NewSignedAmount(ParseBalanceInts(0, 30), true)
+
+	// crossing zero in both directions
+	res, err := debit.Sub(credit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.String() != "-130" {
+		t.Fatalf("unexpected result: %s", res)
+	}
+	if res, err = res.Add(credit); err != nil || res.String() != "-30" {
+		t.Fatalf("unexpected result: %s (%v)", res, err)
+	}
+	if res, err = res.Add(credit); err != nil || res.String() != "70" || res.IsNegative() {
+		t.Fatalf("unexpected result: %s (%v)", res, err)
+	}
+	if res, err = res.Sub(credit); err != nil || res.String() != "-30" || !res.IsNegative() {
+		t.Fatalf("unexpected result: %s (%v)", res, err)
+	}
+
+	// a debit and a credit of the same magnitude cancel out
+	res, err = credit.Add(credit.Neg())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != (SignedAmount{}) || res.IsNegative() || !res.Abs().IsZero() {
+		t.Fatalf("expected zero, got: %s", res)
+	}
+	if NewSignedAmount(ZeroBalance, true).IsNegative() {
+		t.Fatalf("zero should not be negative")
+	}
+
+	max := SignedAmountFromBalance(GenesisBalance)
+	if _, err = max.Add(credit); err != ErrBalanceOverflow {
+		t.Fatalf("expected overflow, got: %v", err)
+	}
+	if _, err = max.Neg().Sub(credit); err != ErrBalanceOverflow {
+		t.Fatalf("expected overflow, got: %v", err)
+	}
+	if res, err = max.Sub(max); err != nil || res != (SignedAmount{}) {
+		t.Fatalf("expected zero, got: %s (%v)", res, err)
+	}
+}
+
+func TestNanoSignedAmountBalance(t *testing.T) {
+	b, err := SignedAmountFromBalance(MaxSupply).Balance()
+	if err != nil || b != MaxSupply {
+		t.Fatalf("expected max supply, got: %s (%v)", b.RawString(), err)
+	}
+
+	if _, err = NewSignedAmount(MaxSupply, true).Balance(); err != ErrNegativeBalance {
+		t.Fatalf("expected negative balance error, got: %v", err)
+	}
+	if b, err = NewSignedAmount(ZeroBalance, true).Balance(); err != nil || !b.IsZero() {
+		t.Fatalf("expected zero, got: %s (%v)", b.RawString(), err)
+	}
+}
+
+func TestNanoSignedAmountJSON(t *testing.T) {
+	tests := map[string]SignedAmount{
+		`"-340282366920938463463374607431768211455"`: NewSignedAmount(GenesisBalance, true),
+		`"1000"`: SignedAmountFromBalance(ParseBalanceInts(0, 1000)),
+		`"0"`:    {},
+	}
+
+	for s, amount := range tests {
+		data, err := json.Marshal(amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != s {
+			t.Errorf("expected: %s, got: %s", s, data)
+		}
+
+		var res SignedAmount
+		if err = json.Unmarshal(data, &res); err != nil {
+			t.Fatal(err)
+		}
+		if res != amount {
+			t.Errorf("expected: %s, got: %s", amount, res)
+		}
+	}
+
+	res := NewSignedAmount(ParseBalanceInts(0, 42), true)
+	if err := res.UnmarshalJSON([]byte(`null`)); err != nil || res != NewSignedAmount(ParseBalanceInts(0, 42), true) {
+		t.Errorf("expected null to keep the amount, got: %s (%v)", res, err)
+	}
+	if err := json.Unmarshal([]byte(`-1000`), &res); err != ErrBalanceMustBeString {
+		t.Errorf("expected balance must be string error, got: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`"-0"`), &res); err != nil || res != (SignedAmount{}) {
+		t.Errorf("expected zero, got: %s (%v)", res, err)
+	}
+	for _, s := range []string{`""`, `"-"`, `"--1"`, `"+1"`, `"1.5"`, `" 1"`} {
+		if err := json.Unmarshal([]byte(s), &res); err != ErrBadBalanceFormat {
+			t.Errorf("(%s) expected bad balance format error, got: %v", s, err)
+		}
+	}
+}
@@ -1,9 +1,16 @@
 package nano
 
 import (
+	"database/sql/driver"
 	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"math/big"
+	"sort"
+	"strings"
 
 	"github.com/shopspring/decimal"
 	"littleriver.cc/go-nano/nano/internal/uint128"
@@ -24,6 +31,20 @@ const (
 	BalanceCompSmaller
 )
 
+// RoundingMode specifies how a balance is rounded when it is formatted with
+// less precision than it has.
+type RoundingMode byte
+
+const (
+	// RoundTruncate discards the digits beyond the requested precision.
+	RoundTruncate RoundingMode = iota
+	// RoundHalfUp rounds to the nearest value, rounding halves up.
+	RoundHalfUp
+	// RoundHalfEven rounds to the nearest value, rounding halves to the nearest
+	// even digit (bankers' rounding).
+	RoundHalfEven
+)
+
 var (
 	units = map[string]decimal.Decimal{
 		"raw":  decimal.New(1, 0),
@@ -33,17 +54,92 @@ var (
 		"kxrb": decimal.New(1, 27),
 		"Mxrb": decimal.New(1, 30),
 		"Gxrb": decimal.New(1, 33),
+
+		// the post-rebrand names, 1 Nano equals 1 Mxrb
+		"unano": decimal.New(1, 24),
+		"mnano": decimal.New(1, 27),
+		"nano":  decimal.New(1, 30),
+		"Nano":  decimal.New(1, 30),
+		"knano": decimal.New(1, 33),
 	}
 
 	ZeroBalance = Balance(uint128.Uint128{})
 
-	ErrBadBalanceSize = errors.New("balances should be 16 bytes in size")
+	// GenesisBalance is the balance the genesis account was opened with: the
+	// largest possible balance.
+	GenesisBalance = ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+	// MaxSupply is the total supply of Nano:
+	// 133248297920938463463374607431768211455 raw, or about 133,248,297.92 Nano.
+	// It is GenesisBalance minus the genesis funds that were burned.
+	MaxSupply = ParseBalanceInts(0x643eb04eac5233c3, 0x7ea82a98bfffffff)
+	// DefaultDustThreshold is the amount below which balances are considered
+	// dust: 10^24 raw, or 0.000001 Nano.
+	DefaultDustThreshold = ParseBalanceInts(0xd3c2, 0x1bcecceda1000000)
+
+	ErrBadBalanceSize   = errors.New("balances should be 16 bytes in size")
+	ErrBalanceOverflow  = errors.New("balance overflow")
+	ErrBalanceUnderflow = errors.New("balance underflow")
+	ErrDivideByZero     = errors.New("balance division by zero")
+	ErrUnknownUnit      = errors.New("unknown balance unit")
+	ErrNegativeBalance  = errors.New("balance is negative")
+	ErrBadBalanceFormat = errors.New("bad balance format")
+	ErrBadBalanceHex    = errors.New("balance hex strings should be 32 characters long")
+	ErrFractionalRaw    = errors.New("balance is not an integer amount of raw")
+
+	ErrBalanceMustBeString = errors.New("balances in json should be strings")
+	ErrZeroTotalWeight     = errors.New("total weight is zero")
+	ErrBadShareCount       = errors.New("number of shares should be positive")
 )
 
+// Balance is a 128 bit unsigned amount of raw. Its exported Hi and Lo fields
+// hold the most and least significant 64 bits, in the same order as the
+// arguments of ParseBalanceInts.
 type Balance uint128.Uint128
 
-// ParseBalance parses the given balance string.
+// BalanceOpError is returned by AddAll and SubAll and records which of the
+// given balances caused an operation to fail.
+type BalanceOpError struct {
+	Index int
+	Err   error
+}
+
+// BalanceAccumulator keeps a running total of balances. Once an operation
+// fails, the error is latched: all following operations are no-ops and Err
+// reports the first error. The zero value is an empty accumulator. It is not
+// safe for concurrent use.
+type BalanceAccumulator struct {
+	total Balance
+	err   error
+}
+
+// FormatOptions controls how FormatUnit renders a balance.
+type FormatOptions struct {
+	// GroupSeparator is placed between every group of three digits in the
+	// integer part. No grouping is done if it is zero.
+	GroupSeparator rune
+	// DecimalSeparator separates the integer and the fractional part. It
+	// defaults to '.' if it is zero.
+	DecimalSeparator rune
+	// Suffix controls whether the unit is appended to the result.
+	Suffix bool
+}
+
+// ParseBalance parses the given balance string. Leading and trailing whitespace
+// is ignored, as are underscores placed between digits to group them. The
+// string may use scientific notation, like "1.3e30". It returns an error
+// wrapping ErrUnknownUnit if the given unit is not known and ErrFractionalRaw if
+// the balance is not a whole amount of raw.
 func ParseBalance(s string, unit string) (Balance, error) {
+	factor, err := unitFactor(unit)
+	if err != nil {
+		return ZeroBalance, err
+	}
+
+	s, err = stripBalanceString(s)
+	if err != nil {
+		return ZeroBalance, err
+	}
+
 	d, err := decimal.NewFromString(s)
 	if err != nil {
 		return ZeroBalance, err
@@ -54,12 +150,40 @@ func ParseBalance(s string, unit string) (Balance, error) {
 		return ZeroBalance, nil
 	}
 
-	d = d.Mul(units[unit])
+	if d.IsNegative() {
+		return ZeroBalance, ErrNegativeBalance
+	}
+
+	d = d.Mul(factor)
 	c := d.Coefficient()
-	f := bigPow(10, int64(d.Exponent()))
-	i := c.Mul(c, f)
+	exp := int64(d.Exponent())
+	if exp >= 0 {
+		return NewBalanceFromBigInt(c.Mul(c, bigPow(10, exp)))
+	}
+
+	// a negative exponent requires dividing, which is only allowed if the
+	// result is a whole amount of raw
+	i, rem := c.QuoRem(c, bigPow(10, -exp), new(big.Int))
+	if rem.Sign() != 0 {
+		return ZeroBalance, ErrFractionalRaw
+	}
+
+	return NewBalanceFromBigInt(i)
+}
+
+// NewBalanceFromBigInt returns the balance for the given raw amount. It returns
+// ErrNegativeBalance if the amount is negative and ErrBalanceOverflow if it
+// does not fit in 128 bits.
+func NewBalanceFromBigInt(i *big.Int) (Balance, error) {
+	if i.Sign() < 0 {
+		return ZeroBalance, ErrNegativeBalance
+	}
 
 	bytes := i.Bytes()
+	if len(bytes) > BalanceSize {
+		return ZeroBalance, ErrBalanceOverflow
+	}
+
 	balanceBytes := make([]byte, BalanceSize)
 	copy(balanceBytes[len(balanceBytes)-len(bytes):], bytes)
 
@@ -71,25 +195,61 @@ func ParseBalance(s string, unit string) (Balance, error) {
 	return balance, nil
 }
 
+// ParseBalanceHex parses the given big-endian hex representation of a balance,
+// as returned by Hex.
+func ParseBalanceHex(s string) (Balance, error) {
+	if len(s) != hex.EncodedLen(BalanceSize) {
+		return ZeroBalance, ErrBadBalanceHex
+	}
+
+	bytes, err := hex.DecodeString(s)
+	if err != nil {
+		return ZeroBalance, err
+	}
+
+	var balance Balance
+	if err = balance.UnmarshalBinary(bytes); err != nil {
+		return ZeroBalance, err
+	}
+
+	return balance, nil
+}
+
 func ParseBalanceInts(hi uint64, lo uint64) Balance {
 	return Balance(uint128.FromInts(hi, lo))
 }
 
+// Ints returns the most and least significant 64 bits of this balance. It is
+// the inverse of ParseBalanceInts.
+func (b Balance) Ints() (hi uint64, lo uint64) {
+	return b.Hi, b.Lo
+}
+
 // Bytes returns the binary representation of this Balance with the given
-// endianness.
+// endianness. It panics if the order is neither binary.BigEndian nor
+// binary.LittleEndian, which includes nil. BytesBE and BytesLE never panic.
 func (b Balance) Bytes(order binary.ByteOrder) []byte {
-	bytes := uint128.Uint128(b).GetBytes()
-
 	switch order {
 	case binary.BigEndian:
-		return bytes
+		return b.BytesBE()
 	case binary.LittleEndian:
-		return util.ReverseBytes(bytes)
+		return b.BytesLE()
 	default:
 		panic("unsupported byte order")
 	}
 }
 
+// BytesBE returns the big-endian binary representation of this balance, as
+// used in block hashes.
+func (b Balance) BytesBE() []byte {
+	return uint128.Uint128(b).GetBytes()
+}
+
+// BytesLE returns the little-endian binary representation of this balance.
+func (b Balance) BytesLE() []byte {
+	return util.ReverseBytes(b.BytesBE())
+}
+
 // Equal reports whether this balance and the given balance are equal.
 func (b Balance) Equal(b2 Balance) bool {
 	return uint128.Uint128(b).Equal(uint128.Uint128(b2))
@@ -99,10 +259,237 @@ func (b Balance) Add(n Balance) Balance {
 	return Balance(uint128.Uint128(b).Add(uint128.Uint128(n)))
 }
 
+// CheckedAdd returns the sum of this balance and the given balance. Unlike Add,
+// it returns ErrBalanceOverflow if the sum does not fit in 128 bits.
+func (b Balance) CheckedAdd(n Balance) (Balance, error) {
+	sum := uint128.Uint128(b).Add(uint128.Uint128(n))
+	// the sum wrapped around if it ended up smaller than one of the operands
+	if sum.Compare(uint128.Uint128(b)) < 0 {
+		return ZeroBalance, ErrBalanceOverflow
+	}
+
+	return Balance(sum), nil
+}
+
 func (b Balance) Sub(n Balance) Balance {
 	return Balance(uint128.Uint128(b).Sub(uint128.Uint128(n)))
 }
 
+// CheckedSub returns the difference between this balance and the given
+// balance. Unlike Sub, it returns ErrBalanceUnderflow if n is bigger than this
+// balance.
+func (b Balance) CheckedSub(n Balance) (Balance, error) {
+	if uint128.Uint128(n).Compare(uint128.Uint128(b)) > 0 {
+		return ZeroBalance, ErrBalanceUnderflow
+	}
+
+	return b.Sub(n), nil
+}
+
+// MulUint64 returns the product of this balance and n. It returns
+// ErrBalanceOverflow if the product does not fit in 128 bits.
+func (b Balance) MulUint64(n uint64) (Balance, error) {
+	product, overflow := uint128.Uint128(b).Mul64(n)
+	if overflow {
+		return ZeroBalance, ErrBalanceOverflow
+	}
+
+	return Balance(product), nil
+}
+
+// MulDecimal returns the product of this balance and the given fraction,
+// truncated toward zero to a whole number of raw. It returns ErrBalanceOverflow
+// if the product does not fit in 128 bits.
+func (b Balance) MulDecimal(frac decimal.Decimal) (Balance, error) {
+	if frac.IsNegative() {
+		return ZeroBalance, ErrNegativeBalance
+	}
+
+	d := decimal.NewFromBigInt(b.BigInt(), 0).Mul(frac).Truncate(0)
+	c := d.Coefficient()
+	if exp := d.Exponent(); exp > 0 {
+		c.Mul(c, bigPow(10, int64(exp)))
+	}
+
+	return NewBalanceFromBigInt(c)
+}
+
+// DivMod returns the quotient and remainder of dividing this balance by the
+// given divisor. It returns ErrDivideByZero if the divisor is zero.
+func (b Balance) DivMod(divisor uint64) (quotient Balance, remainder uint64, err error) {
+	if divisor == 0 {
+		return ZeroBalance, 0, ErrDivideByZero
+	}
+
+	q, r := uint128.Uint128(b).DivMod64(divisor)
+	return Balance(q), r, nil
+}
+
+// SplitProportional splits this balance into parts proportional to the given
+// weights. The parts always sum up to this balance: the raw left over after
+// rounding down is handed out one by one to the parts with the largest
+// remainders, with ties going to the earlier part. It returns
+// ErrZeroTotalWeight if the weights sum up to zero.
+func (b Balance) SplitProportional(weights []uint64) ([]Balance, error) {
+	total := new(big.Int)
+	for _, weight := range weights {
+		total.Add(total, new(big.Int).SetUint64(weight))
+	}
+	if total.Sign() == 0 {
+		return nil, ErrZeroTotalWeight
+	}
+
+	amount := b.BigInt()
+	parts := make([]Balance, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	left := b
+	for i, weight := range weights {
+		q, r := new(big.Int).QuoRem(
+			new(big.Int).Mul(amount, new(big.Int).SetUint64(weight)), total, new(big.Int))
+
+		// a part never exceeds the balance, so neither call can fail
+		part, err := NewBalanceFromBigInt(q)
+		if err != nil {
+			panic(err)
+		}
+		if left, err = left.CheckedSub(part); err != nil {
+			panic(err)
+		}
+		parts[i], remainders[i] = part, r
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+
+	// less raw is left over than there are parts
+	one := ParseBalanceInts(0, 1)
+	for i := 0; !left.IsZero(); i++ {
+		parts[order[i]] = parts[order[i]].Add(one)
+		left = left.Sub(one)
+	}
+
+	return parts, nil
+}
+
+// SplitN splits this balance into n equal shares that sum up to this balance.
+// If the balance is not divisible by n, the first shares get one extra raw. It
+// returns ErrBadShareCount if n is not positive.
+func (b Balance) SplitN(n int) ([]Balance, error) {
+	if n <= 0 {
+		return nil, ErrBadShareCount
+	}
+
+	share, remainder, err := b.DivMod(uint64(n))
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]Balance, n)
+	for i := range shares {
+		shares[i] = share
+		if uint64(i) < remainder {
+			shares[i] = share.Add(ParseBalanceInts(0, 1))
+		}
+	}
+
+	return shares, nil
+}
+
+// Add adds the given balance to the total, unless an error occurred before.
+func (a *BalanceAccumulator) Add(n Balance) {
+	if a.err == nil {
+		a.total, a.err = a.total.CheckedAdd(n)
+	}
+}
+
+// Sub subtracts the given balance from the total, unless an error occurred
+// before.
+func (a *BalanceAccumulator) Sub(n Balance) {
+	if a.err == nil {
+		a.total, a.err = a.total.CheckedSub(n)
+	}
+}
+
+// Total returns the running total. It is zero if an error occurred.
+func (a *BalanceAccumulator) Total() Balance {
+	if a.err != nil {
+		return ZeroBalance
+	}
+	return a.total
+}
+
+// Err returns the first error that occurred, if any.
+func (a *BalanceAccumulator) Err() error {
+	return a.err
+}
+
+// BalanceDelta returns the absolute difference between the given previous and
+// current balance, and whether the balance increased. The amount is zero if the
+// balances are equal.
+func BalanceDelta(prev, cur Balance) (amount Balance, increased bool) {
+	if cur.GreaterThan(prev) {
+		amount, err := cur.CheckedSub(prev)
+		if err != nil {
+			panic(err)
+		}
+		return amount, true
+	}
+
+	amount, err := prev.CheckedSub(cur)
+	if err != nil {
+		panic(err)
+	}
+	return amount, false
+}
+
+// Sum returns the sum of the given balances. It returns ErrBalanceOverflow if
+// the sum does not fit in 128 bits.
+func Sum(balances []Balance) (Balance, error) {
+	sum := ZeroBalance
+	for _, b := range balances {
+		var err error
+		if sum, err = sum.CheckedAdd(b); err != nil {
+			return ZeroBalance, err
+		}
+	}
+
+	return sum, nil
+}
+
+// AddAll returns the sum of the given balances. If the sum overflows, a
+// *BalanceOpError is returned with the index of the balance that caused it.
+func AddAll(balances ...Balance) (Balance, error) {
+	sum := ZeroBalance
+	for i, b := range balances {
+		var err error
+		if sum, err = sum.CheckedAdd(b); err != nil {
+			return ZeroBalance, &BalanceOpError{Index: i, Err: err}
+		}
+	}
+
+	return sum, nil
+}
+
+// SubAll subtracts the given amounts from the given balance in order. If a
+// subtraction underflows, a *BalanceOpError is returned with the index of the
+// amount that caused it.
+func SubAll(from Balance, amounts ...Balance) (Balance, error) {
+	res := from
+	for i, b := range amounts {
+		var err error
+		if res, err = res.CheckedSub(b); err != nil {
+			return ZeroBalance, &BalanceOpError{Index: i, Err: err}
+		}
+	}
+
+	return res, nil
+}
+
 func (b Balance) Compare(n Balance) BalanceComp {
 	res := uint128.Uint128(b).Compare(uint128.Uint128(n))
 	switch res {
@@ -117,6 +504,88 @@ func (b Balance) Compare(n Balance) BalanceComp {
 	}
 }
 
+// Cmp compares this balance to the given balance and returns -1 if it is
+// smaller, 0 if they are equal and 1 if it is bigger.
+func (b Balance) Cmp(n Balance) int {
+	return uint128.Uint128(b).Compare(uint128.Uint128(n))
+}
+
+// GreaterThan reports whether this balance is bigger than the given balance.
+func (b Balance) GreaterThan(n Balance) bool {
+	return b.Compare(n) == BalanceCompBigger
+}
+
+// GreaterThanOrEqual reports whether this balance is bigger than or equal to the
+// given balance.
+func (b Balance) GreaterThanOrEqual(n Balance) bool {
+	return b.Compare(n) != BalanceCompSmaller
+}
+
+// LessThan reports whether this balance is smaller than the given balance.
+func (b Balance) LessThan(n Balance) bool {
+	return b.Compare(n) == BalanceCompSmaller
+}
+
+// LessThanOrEqual reports whether this balance is smaller than or equal to the
+// given balance.
+func (b Balance) LessThanOrEqual(n Balance) bool {
+	return b.Compare(n) != BalanceCompBigger
+}
+
+// IsZero reports whether this balance is zero.
+func (b Balance) IsZero() bool {
+	return b == ZeroBalance
+}
+
+// IsDust reports whether this balance is smaller than the given threshold, like
+// DefaultDustThreshold.
+func (b Balance) IsDust(threshold Balance) bool {
+	return b.Cmp(threshold) < 0
+}
+
+// Min returns the smaller of the given balances.
+func Min(a, b Balance) Balance {
+	if a.Compare(b) == BalanceCompBigger {
+		return b
+	}
+	return a
+}
+
+// Max returns the bigger of the given balances.
+func Max(a, b Balance) Balance {
+	if a.Compare(b) == BalanceCompSmaller {
+		return b
+	}
+	return a
+}
+
+func (e *BalanceOpError) Error() string {
+	return fmt.Sprintf("balance at index %d: %s", e.Index, e.Err)
+}
+
+func (e *BalanceOpError) Unwrap() error {
+	return e.Err
+}
+
+// ProportionOf returns the fraction of the given total this balance represents.
+// It returns zero if the total is zero.
+func (b Balance) ProportionOf(total Balance) decimal.Decimal {
+	if total.Equal(ZeroBalance) {
+		return decimal.Zero
+	}
+
+	// use enough precision to keep a single raw of the max balance from being
+	// rounded to zero
+	d := decimal.NewFromBigInt(b.BigInt(), 0)
+	return d.DivRound(decimal.NewFromBigInt(total.BigInt(), 0), BalanceMaxPrecision*2)
+}
+
+// PercentOf returns the percentage of the given total this balance
+// represents. It returns zero if the total is zero.
+func (b Balance) PercentOf(total Balance) decimal.Decimal {
+	return b.ProportionOf(total).Shift(2)
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (b Balance) MarshalBinary() ([]byte, error) {
 	return b.Bytes(binary.LittleEndian), nil
@@ -125,24 +594,123 @@ func (b Balance) MarshalBinary() ([]byte, error) {
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
 func (b *Balance) UnmarshalBinary(data []byte) error {
 	if len(data) != BalanceSize {
-		return ErrBadBalanceSize
+		return badBalanceSize(len(data))
 	}
 
 	*b = Balance(uint128.FromBytes(data))
 	return nil
 }
 
+// DecodeBalances decodes the given buffer of consecutive balances in the little
+// endian encoding of MarshalBinary. The length of the buffer must be a multiple
+// of BalanceSize. Only the result is allocated, which makes it suitable for
+// large dumps.
+func DecodeBalances(data []byte) ([]Balance, error) {
+	if len(data)%BalanceSize != 0 {
+		return nil, fmt.Errorf("%w: got %d bytes, not a multiple of %d", ErrBadBalanceSize, len(data), BalanceSize)
+	}
+
+	balances := make([]Balance, len(data)/BalanceSize)
+	for i := range balances {
+		record := data[i*BalanceSize : (i+1)*BalanceSize]
+		balances[i] = ParseBalanceInts(binary.LittleEndian.Uint64(record[8:]), binary.LittleEndian.Uint64(record[:8]))
+	}
+
+	return balances, nil
+}
+
+// EncodeBalances writes the given balances to w in the little endian encoding of
+// MarshalBinary, the format read by DecodeBalances. It stops at the first error
+// returned by w.
+func EncodeBalances(w io.Writer, balances []Balance) error {
+	var buf [BalanceSize]byte
+	for _, b := range balances {
+		binary.LittleEndian.PutUint64(buf[:8], b.Lo)
+		binary.LittleEndian.PutUint64(buf[8:], b.Hi)
+		if _, err := w.Write(buf[:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface. It uses the same little
+// endian encoding as MarshalBinary.
+func (b Balance) GobEncode() ([]byte, error) {
+	return b.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (b *Balance) GobDecode(data []byte) error {
+	if len(data) != BalanceSize {
+		return badBalanceSize(len(data))
+	}
+
+	bytes := make([]byte, BalanceSize)
+	copy(bytes, data)
+	return b.UnmarshalBinary(util.ReverseBytes(bytes))
+}
+
+// Hex returns the big-endian hex representation of this balance.
+func (b Balance) Hex() string {
+	return hex.EncodeToString(b.Bytes(binary.BigEndian))
+}
+
 func (b Balance) BigInt() *big.Int {
 	i := big.NewInt(0)
 	i.SetBytes(b.Bytes(binary.BigEndian))
 	return i
 }
 
+// RawString returns the exact amount of raw in this balance as a decimal
+// integer. This is the lossless canonical form of a balance, parsing it with
+// ParseBalance in raw always yields the same balance.
+func (b Balance) RawString() string {
+	return b.BigInt().String()
+}
+
 // UnitString returns a decimal representation of this uint128 converted to the
-// given unit.
+// given unit. Digits beyond the given precision are truncated. It panics if the
+// given unit is not known.
+//
+// The result is lossy if the precision is smaller than the amount of decimals
+// of the unit, for example 30 for Mxrb. A precision of BalanceMaxPrecision is
+// enough for every unit. Use RawString for a representation that is always
+// exact.
 func (b Balance) UnitString(unit string, precision int32) string {
-	d := decimal.NewFromBigInt(b.BigInt(), 0)
-	return d.DivRound(units[unit], BalanceMaxPrecision).Truncate(precision).String()
+	return b.UnitStringRounded(unit, precision, RoundTruncate)
+}
+
+// UnitStringRounded is like UnitString, but rounds the result to the given
+// precision using the given rounding mode.
+func (b Balance) UnitStringRounded(unit string, precision int32, mode RoundingMode) string {
+	return b.unitDecimal(unit, precision, mode).String()
+}
+
+// UnitStringFixed is like UnitString, but pads the result with trailing zeros
+// to exactly the given number of fractional digits.
+func (b Balance) UnitStringFixed(unit string, precision int32) string {
+	return b.unitDecimal(unit, precision, RoundTruncate).StringFixed(precision)
+}
+
+func (b Balance) unitDecimal(unit string, precision int32, mode RoundingMode) decimal.Decimal {
+	factor, err := unitFactor(unit)
+	if err != nil {
+		panic(err)
+	}
+
+	d := decimal.NewFromBigInt(b.BigInt(), 0).DivRound(factor, BalanceMaxPrecision)
+	switch mode {
+	case RoundTruncate:
+		return d.Truncate(precision)
+	case RoundHalfUp:
+		return d.Round(precision)
+	case RoundHalfEven:
+		return d.RoundBank(precision)
+	default:
+		panic("unsupported rounding mode")
+	}
 }
 
 // String implements the fmt.Stringer interface. It returns the balance in Mxrb
@@ -151,18 +719,251 @@ func (b Balance) String() string {
 	return b.UnitString("Mxrb", BalanceMaxPrecision)
 }
 
+// humanizeSuffixes holds the unit suffix used by Humanize for every power of
+// 1000 raw. Between raw and unano, and above knano, there are no registered
+// units, so those suffixes are display-only labels.
+var humanizeSuffixes = []string{
+	"raw", "kraw", "Mraw", "Graw", "Traw", "Praw", "Eraw", "Zraw",
+	"unano", "mnano", "Nano", "knano", "Mnano",
+}
+
+// Humanize returns an approximate representation of this balance that is
+// suitable for display, like "1.23 knano" or "456 raw". The unit is picked
+// based on the magnitude of the balance and the result is truncated to three
+// significant digits. Balances below 1 Nano are shown in smaller units.
+//
+// The raw, unano, mnano, Nano and knano suffixes are units known to
+// ParseBalance. The suffixes kraw to Zraw and Mnano are display-only labels
+// that ParseBalance and Units don't accept.
+func (b Balance) Humanize() string {
+	i := b.BigInt()
+	if i.Sign() == 0 {
+		return "0 raw"
+	}
+
+	exp := int32(len(i.String()) - 1)
+	step := exp / 3
+	if max := int32(len(humanizeSuffixes) - 1); step > max {
+		step = max
+	}
+
+	d := decimal.NewFromBigInt(i, -step*3).Truncate(2 - (exp - step*3))
+	return d.String() + " " + humanizeSuffixes[step]
+}
+
+// FormatUnit returns a representation of this balance converted to the given
+// unit that is suitable for display, as specified by opts. Digits beyond the
+// given precision are truncated. It panics if the given unit is not known.
+func (b Balance) FormatUnit(unit string, precision int32, opts FormatOptions) string {
+	s := b.UnitString(unit, precision)
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+
+	var buf strings.Builder
+	for i, c := range intPart {
+		if opts.GroupSeparator != 0 && i != 0 && (len(intPart)-i)%3 == 0 {
+			buf.WriteRune(opts.GroupSeparator)
+		}
+		buf.WriteRune(c)
+	}
+
+	if fracPart != "" {
+		if opts.DecimalSeparator != 0 {
+			buf.WriteRune(opts.DecimalSeparator)
+		} else {
+			buf.WriteByte('.')
+		}
+		buf.WriteString(fracPart)
+	}
+
+	if opts.Suffix {
+		buf.WriteByte(' ')
+		buf.WriteString(unit)
+	}
+
+	return buf.String()
+}
+
+// stripBalanceString removes surrounding whitespace and digit group separators
+// from the given balance string.
+func stripBalanceString(s string) (string, error) {
+	s = strings.Trim(s, " \t\n\r\v\f")
+	if !strings.ContainsRune(s, '_') {
+		return s, nil
+	}
+
+	isDigit := func(i int) bool {
+		return i >= 0 && i < len(s) && s[i] >= '0' && s[i] <= '9'
+	}
+
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '_' {
+			buf.WriteByte(s[i])
+			continue
+		}
+
+		// only allow underscores in between two digits
+		if !isDigit(i-1) || !isDigit(i+1) {
+			return "", ErrBadBalanceFormat
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// isRawInteger reports whether the given string only consists of digits.
+func isRawInteger(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// badBalanceSize returns an error wrapping ErrBadBalanceSize that includes the
+// actual size.
+func badBalanceSize(size int) error {
+	return fmt.Errorf("%w: got %d bytes", ErrBadBalanceSize, size)
+}
+
+// Units returns the sorted names of all known units.
+func Units() []string {
+	names := make([]string, 0, len(units))
+	for name := range units {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UnitFactor returns the amount of raw in one of the given unit. It reports
+// whether the unit is known.
+func UnitFactor(unit string) (decimal.Decimal, bool) {
+	factor, ok := units[unit]
+	return factor, ok
+}
+
+func unitFactor(unit string) (decimal.Decimal, error) {
+	factor, ok := UnitFactor(unit)
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("%w: %q", ErrUnknownUnit, unit)
+	}
+	return factor, nil
+}
+
+// bigPow returns base to the power of exp. It panics if exp is negative.
 func bigPow(base int64, exp int64) *big.Int {
-	return new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil)
+	if exp < 0 {
+		panic("negative exponent")
+	}
+	return new(big.Int).Exp(big.NewInt(base), big.NewInt(exp), nil)
 }
 
-// MarshalText implements the encoding.TextMarshaler interface.
+// MarshalText implements the encoding.TextMarshaler interface. It encodes the
+// balance in Mxrb with maximum precision.
 func (b Balance) MarshalText() ([]byte, error) {
-	return []byte(b.String()), nil
+	return b.MarshalTextUnit("Mxrb", BalanceMaxPrecision)
+}
+
+// MarshalTextUnit is like MarshalText, but encodes the balance in the given unit
+// with the given precision. Use "raw" for an exact representation.
+func (b Balance) MarshalTextUnit(unit string, precision int32) ([]byte, error) {
+	if _, err := unitFactor(unit); err != nil {
+		return nil, err
+	}
+
+	return []byte(b.UnitString(unit, precision)), nil
 }
 
-// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It expects
+// the balance to be in Mxrb.
 func (b *Balance) UnmarshalText(text []byte) error {
-	balance, err := ParseBalance(string(text), "Mxrb")
+	return b.UnmarshalTextUnit(text, "Mxrb")
+}
+
+// UnmarshalTextUnit is like UnmarshalText, but expects the balance to be in
+// the given unit.
+func (b *Balance) UnmarshalTextUnit(text []byte, unit string) error {
+	balance, err := ParseBalance(string(text), unit)
+	if err != nil {
+		return err
+	}
+
+	*b = balance
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. Unlike MarshalText, it
+// encodes the balance as a raw integer string to match the format of the node
+// RPC.
+func (b Balance) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.RawString())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It only accepts a
+// raw integer string and returns ErrBalanceMustBeString for JSON numbers, which
+// many decoders cannot represent without losing precision. Like
+// encoding/json, it leaves the balance unchanged for null.
+func (b *Balance) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) == 0 || data[0] != '"' {
+		return ErrBalanceMustBeString
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if !isRawInteger(s) {
+		return ErrBadBalanceFormat
+	}
+
+	balance, err := ParseBalance(s, "raw")
+	if err != nil {
+		return err
+	}
+
+	*b = balance
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The balance is stored as a raw
+// integer string.
+func (b Balance) Value() (driver.Value, error) {
+	return b.RawString(), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts raw integer values in
+// the form of a string, a byte slice or an int64.
+func (b *Balance) Scan(src interface{}) error {
+	var balance Balance
+	var err error
+
+	switch v := src.(type) {
+	case nil:
+		balance = ZeroBalance
+	case string:
+		balance, err = ParseBalance(v, "raw")
+	case []byte:
+		balance, err = ParseBalance(string(v), "raw")
+	case int64:
+		if v < 0 {
+			return ErrNegativeBalance
+		}
+		balance = ParseBalanceInts(0, uint64(v))
+	default:
+		return fmt.Errorf("unsupported balance source type: %T", src)
+	}
 	if err != nil {
 		return err
 	}
@@ -1,7 +1,22 @@
 package nano
 
 import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/shopspring/decimal"
 )
 
 func TestNanoBalance(t *testing.T) {
@@ -15,6 +30,12 @@ func TestNanoBalance(t *testing.T) {
 		"kxrb": "340282366920.938463463374607431768211455",
 		"Mxrb": "340282366.920938463463374607431768211455",
 		"Gxrb": "340282.366920938463463374607431768211455",
+
+		"unano": "340282366920938.463463374607431768211455",
+		"mnano": "340282366920.938463463374607431768211455",
+		"nano":  "340282366.920938463463374607431768211455",
+		"Nano":  "340282366.920938463463374607431768211455",
+		"knano": "340282.366920938463463374607431768211455",
 	}
 	b2Units := map[string]string{
 		"raw":  "1",
@@ -24,6 +45,12 @@ func TestNanoBalance(t *testing.T) {
 		"kxrb": "0.000000000000000000000000001",
 		"Mxrb": "0.000000000000000000000000000001",
 		"Gxrb": "0.000000000000000000000000000000001",
+
+		"unano": "0.000000000000000000000001",
+		"mnano": "0.000000000000000000000000001",
+		"nano":  "0.000000000000000000000000000001",
+		"Nano":  "0.000000000000000000000000000001",
+		"knano": "0.000000000000000000000000000000001",
 	}
 	b1TruncatedUnits := map[string]string{
 		"raw":  "340282366920938463463374607431768211455",
@@ -33,6 +60,12 @@ func TestNanoBalance(t *testing.T) {
 		"kxrb": "340282366920.938463",
 		"Mxrb": "340282366.920938",
 		"Gxrb": "340282.36692",
+
+		"unano": "340282366920938.463463",
+		"mnano": "340282366920.938463",
+		"nano":  "340282366.920938",
+		"Nano":  "340282366.920938",
+		"knano": "340282.36692",
 	}
 
 	compare := func(b Balance, m map[string]string, p int32) {
@@ -77,3 +110,1375 @@ func TestNanoBalance(t *testing.T) {
 		}
 	}
 }
+
+func TestNanoBalanceCheckedAdd(t *testing.T) {
+	max := ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+	one := ParseBalanceInts(0, 1)
+
+	sum, err := max.CheckedAdd(ZeroBalance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sum.Equal(max) {
+		t.Fatalf("expected: %s, got: %s", max, sum)
+	}
+
+	if _, err = max.CheckedAdd(one); err != ErrBalanceOverflow {
+		t.Fatalf("expected overflow for max + 1, got: %v", err)
+	}
+
+	if _, err = max.CheckedAdd(max); err != ErrBalanceOverflow {
+		t.Fatalf("expected overflow for max + max, got: %v", err)
+	}
+
+	sum, err = ParseBalanceInts(0, 0xffffffffffffffff).CheckedAdd(one)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sum.Equal(ParseBalanceInts(1, 0)) {
+		t.Fatalf("carry was not propagated: %s", sum.UnitString("raw", 0))
+	}
+}
+
+func TestNanoBalanceCheckedSub(t *testing.T) {
+	b := ParseBalanceInts(1, 0)
+	one := ParseBalanceInts(0, 1)
+
+	diff, err := b.CheckedSub(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Equal(ZeroBalance) {
+		t.Fatalf("expected zero, got: %s", diff)
+	}
+
+	diff, err = b.CheckedSub(one)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Equal(ParseBalanceInts(0, 0xffffffffffffffff)) {
+		t.Fatalf("borrow was not propagated: %s", diff.UnitString("raw", 0))
+	}
+
+	if _, err = ZeroBalance.CheckedSub(one); err != ErrBalanceUnderflow {
+		t.Fatalf("expected underflow for 0 - 1, got: %v", err)
+	}
+
+	if _, err = b.CheckedSub(b.Add(one)); err != ErrBalanceUnderflow {
+		t.Fatalf("expected underflow for b - (b+1), got: %v", err)
+	}
+}
+
+func TestNanoBalanceMulUint64(t *testing.T) {
+	b := ParseBalanceInts(0x1, 0x8000000000000001)
+
+	cases := map[uint64]Balance{
+		0: ZeroBalance,
+		1: b,
+		2: ParseBalanceInts(0x3, 0x2),
+	}
+
+	for n, expected := range cases {
+		product, err := b.MulUint64(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !product.Equal(expected) {
+			t.Errorf("(%d) expected: %s, got: %s", n, expected.UnitString("raw", 0), product.UnitString("raw", 0))
+		}
+	}
+
+	// (2^127) * 2 is the smallest product that no longer fits
+	top := ParseBalanceInts(0x8000000000000000, 0)
+	if _, err := top.MulUint64(2); err != ErrBalanceOverflow {
+		t.Fatalf("expected overflow, got: %v", err)
+	}
+
+	product, err := ParseBalanceInts(0x7fffffffffffffff, 0xffffffffffffffff).MulUint64(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !product.Equal(ParseBalanceInts(0xffffffffffffffff, 0xfffffffffffffffe)) {
+		t.Fatalf("unexpected product: %s", product.UnitString("raw", 0))
+	}
+}
+
+func TestNanoBalanceDivMod(t *testing.T) {
+	b := ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+
+	for _, divisor := range []uint64{1, 2, 3, 7, 10, 1000000007, 0xffffffffffffffff} {
+		q, r, err := b.DivMod(divisor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r >= divisor {
+			t.Errorf("(%d) remainder too large: %d", divisor, r)
+		}
+
+		product, err := q.MulUint64(divisor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := product.CheckedAdd(ParseBalanceInts(0, r))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(b) {
+			t.Errorf("(%d) quotient*divisor + remainder != original: %s", divisor, res.UnitString("raw", 0))
+		}
+	}
+
+	if _, _, err := b.DivMod(0); err != ErrDivideByZero {
+		t.Fatalf("expected division by zero error, got: %v", err)
+	}
+}
+
+func TestNanoBalanceUnknownUnit(t *testing.T) {
+	for _, unit := range []string{"bogus", ""} {
+		if _, err := ParseBalance("5", unit); !errors.Is(err, ErrUnknownUnit) {
+			t.Errorf("(%q) expected unknown unit error, got: %v", unit, err)
+		}
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected UnitString to panic on an unknown unit")
+		}
+	}()
+	ZeroBalance.UnitString("bogus", BalanceMaxPrecision)
+}
+
+func TestNanoBalanceNegative(t *testing.T) {
+	b, err := ParseBalance("-0", "raw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Equal(ZeroBalance) {
+		t.Fatalf("expected zero, got: %s", b)
+	}
+
+	for _, s := range []string{"-0.0001", "-1", "-340282366920938463463374607431768211455"} {
+		if _, err := ParseBalance(s, "Mxrb"); err != ErrNegativeBalance {
+			t.Errorf("(%s) expected negative balance error, got: %v", s, err)
+		}
+	}
+}
+
+func TestNanoBalanceParseOverflow(t *testing.T) {
+	b, err := ParseBalance("340282366920938463463374607431768211455", "raw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Equal(ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)) {
+		t.Fatalf("unexpected balance: %s", b.UnitString("raw", 0))
+	}
+
+	overflows := map[string]string{
+		"340282366920938463463374607431768211456":                                        "raw",
+		"340282366.920938463463374607431768211456":                                       "Mxrb",
+		"999999999999999999999999999999999999999999999999999999999999999999999999999999": "raw",
+	}
+	for s, unit := range overflows {
+		if _, err := ParseBalance(s, unit); err != ErrBalanceOverflow {
+			t.Errorf("(%s) expected overflow, got: %v", s, err)
+		}
+	}
+}
+
+func TestNanoBalanceParseScientific(t *testing.T) {
+	type scientificTest struct {
+		balance string
+		unit    string
+		raw     string
+	}
+
+	tests := []scientificTest{
+		{"1e30", "raw", "1000000000000000000000000000000"},
+		{"1.3e30", "raw", "1300000000000000000000000000000"},
+		{"1.5e-3", "nano", "1500000000000000000000000000"},
+		{"2E3", "Mxrb", "2000000000000000000000000000000000"},
+		{"1e-30", "Mxrb", "1"},
+		{"100e-2", "raw", "1"},
+		{"1500e-3", "xrb", "1500000000000000000000000"},
+		{"3402823669209384634633746074317682114550e-1", "raw", "340282366920938463463374607431768211455"},
+	}
+
+	for _, test := range tests {
+		b, err := ParseBalance(test.balance, test.unit)
+		if err != nil {
+			t.Errorf("(%s %s) %s", test.balance, test.unit, err)
+			continue
+		}
+		if res := b.RawString(); res != test.raw {
+			t.Errorf("(%s %s) expected: %s, got: %s", test.balance, test.unit, test.raw, res)
+		}
+	}
+
+	fractional := map[string]string{
+		"1.5":     "raw",
+		"0.1":     "raw",
+		"1e-1":    "raw",
+		"1.5e-30": "Mxrb",
+		"34028236692093846346337460743176821145.5": "raw",
+	}
+	for s, unit := range fractional {
+		if _, err := ParseBalance(s, unit); err != ErrFractionalRaw {
+			t.Errorf("(%s %s) expected fractional raw error, got: %v", s, unit, err)
+		}
+	}
+}
+
+func TestNanoBalanceParseFractional(t *testing.T) {
+	fractional := map[string]string{
+		"0.5":                               "raw",
+		"1.000000001":                       "raw",
+		"0.0000000000000000001":             "uxrb",
+		"0.0000000000000000000000000000001": "Nano",
+		"1.0000000000000000000000000000001": "Mxrb",
+	}
+	for s, unit := range fractional {
+		if _, err := ParseBalance(s, unit); err != ErrFractionalRaw {
+			t.Errorf("(%s %s) expected fractional raw error, got: %v", s, unit, err)
+		}
+	}
+
+	// the smallest fractions each unit allows
+	valid := map[string]string{
+		"1.0":                                   "1",
+		"0.0000001 uxrb":                        "100000000000",
+		"0.000000000000000001 uxrb":             "1",
+		"0.000000000000000000000000000001 Nano": "1",
+		"1.000000000000000000000000000001 Mxrb": "1000000000000000000000000000001",
+	}
+	for s, raw := range valid {
+		unit := "raw"
+		if i := strings.IndexByte(s, ' '); i != -1 {
+			s, unit = s[:i], s[i+1:]
+		}
+
+		b, err := ParseBalance(s, unit)
+		if err != nil {
+			t.Errorf("(%s %s) %s", s, unit, err)
+			continue
+		}
+		if b.RawString() != raw {
+			t.Errorf("(%s %s) expected: %s, got: %s", s, unit, raw, b.RawString())
+		}
+	}
+}
+
+func TestNanoBalanceUnits(t *testing.T) {
+	names := Units()
+	if len(names) != len(units) || !sort.StringsAreSorted(names) {
+		t.Fatalf("unexpected units: %v", names)
+	}
+
+	for _, name := range names {
+		factor, ok := UnitFactor(name)
+		if !ok || !factor.Equal(units[name]) {
+			t.Errorf("(%s) unexpected factor: %s (%t)", name, factor, ok)
+		}
+	}
+
+	factor, ok := UnitFactor("Nano")
+	if !ok || factor.String() != "1000000000000000000000000000000" {
+		t.Errorf("unexpected factor of Nano: %s (%t)", factor, ok)
+	}
+	for _, name := range []string{"", "NANO", "xno", "raws"} {
+		if _, ok := UnitFactor(name); ok {
+			t.Errorf("(%q) unknown unit reported as known", name)
+		}
+	}
+}
+
+func TestNanoBalanceBigPow(t *testing.T) {
+	if res := bigPow(2, 10); res.Int64() != 1024 {
+		t.Errorf("expected 1024, got: %s", res)
+	}
+	if res := bigPow(10, 0); res.Int64() != 1 {
+		t.Errorf("expected 1, got: %s", res)
+	}
+}
+
+func TestNanoBalanceUnitStringRounded(t *testing.T) {
+	type roundingTest struct {
+		balance   string
+		precision int32
+		results   map[RoundingMode]string
+	}
+
+	tests := []roundingTest{
+		{"0.125", 2, map[RoundingMode]string{RoundTruncate: "0.12", RoundHalfUp: "0.13", RoundHalfEven: "0.12"}},
+		{"0.135", 2, map[RoundingMode]string{RoundTruncate: "0.13", RoundHalfUp: "0.14", RoundHalfEven: "0.14"}},
+		{"0.1251", 2, map[RoundingMode]string{RoundTruncate: "0.12", RoundHalfUp: "0.13", RoundHalfEven: "0.13"}},
+		{"0.9999999", 6, map[RoundingMode]string{RoundTruncate: "0.999999", RoundHalfUp: "1", RoundHalfEven: "1"}},
+	}
+
+	for _, test := range tests {
+		b, err := ParseBalance(test.balance, "Mxrb")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for mode, s := range test.results {
+			res := b.UnitStringRounded("Mxrb", test.precision, mode)
+			if res != s {
+				t.Errorf("(%s, mode %d) expected: %s, got: %s", test.balance, mode, s, res)
+			}
+		}
+
+		if b.UnitString("Mxrb", test.precision) != test.results[RoundTruncate] {
+			t.Errorf("(%s) UnitString does not truncate", test.balance)
+		}
+	}
+}
+
+func TestNanoBalanceFormatUnit(t *testing.T) {
+	opts := FormatOptions{GroupSeparator: ',', Suffix: true}
+	tests := map[string]string{
+		"0":                 "0 Nano",
+		"0.000012":          "0.000012 Nano",
+		"999":               "999 Nano",
+		"1234567.890123456": "1,234,567.890123 Nano",
+		"340282366.920938463463374607431768211455": "340,282,366.920938 Nano",
+	}
+
+	for s, expected := range tests {
+		b, err := ParseBalance(s, "Nano")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res := b.FormatUnit("Nano", 6, opts); res != expected {
+			t.Errorf("expected: %s, got: %s", expected, res)
+		}
+	}
+
+	b := ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+	res := b.FormatUnit("raw", 0, FormatOptions{GroupSeparator: '.', DecimalSeparator: ','})
+	if res != "340.282.366.920.938.463.463.374.607.431.768.211.455" {
+		t.Errorf("unexpected result: %s", res)
+	}
+
+	res = b.FormatUnit("Gxrb", 3, FormatOptions{GroupSeparator: ' ', DecimalSeparator: ','})
+	if res != "340 282,366" {
+		t.Errorf("unexpected result: %s", res)
+	}
+}
+
+func TestNanoBalanceHumanize(t *testing.T) {
+	tests := map[string]string{
+		"0":                                  "0 raw",
+		"1":                                  "1 raw",
+		"456":                                "456 raw",
+		"1234":                               "1.23 kraw",
+		"10000000":                           "10 Mraw",
+		"999999999999999999999":              "999 Eraw",
+		"1000000000000000000000000":          "1 unano",
+		"1500000000000000000000000000":       "1.5 mnano",
+		"120000000000000000000000000000":     "120 mnano",
+		"1000000000000000000000000000000":    "1 Nano",
+		"12345678900000000000000000000000":   "12.3 Nano",
+		"1230000000000000000000000000000000": "1.23 knano",
+		"133248297000000000000000000000000000000": "133 Mnano",
+		"340282366920938463463374607431768211455": "340 Mnano",
+	}
+
+	for s, expected := range tests {
+		b, err := ParseBalance(s, "raw")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res := b.Humanize(); res != expected {
+			t.Errorf("expected: %s, got: %s", expected, res)
+		}
+	}
+
+	if res := MaxSupply.Humanize(); res != "133 Mnano" {
+		t.Errorf("unexpected result: %s", res)
+	}
+
+	// the Nano scale suffixes are registered units, so the output parses back
+	// to the truncated balance
+	for s, expected := range map[string]string{
+		"1500000000000000000000000":          "1500000000000000000000000",
+		"1999999999999999999999999999":       "1990000000000000000000000000",
+		"12345678900000000000000000000000":   "12300000000000000000000000000000",
+		"1230000000000000000000000000000000": "1230000000000000000000000000000000",
+	} {
+		b, err := ParseBalance(s, "raw")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields := strings.Fields(b.Humanize())
+		parsed, err := ParseBalance(fields[0], fields[1])
+		if err != nil {
+			t.Fatalf("(%s) %v", b.Humanize(), err)
+		}
+		if res := parsed.RawString(); res != expected {
+			t.Errorf("(%s) expected: %s, got: %s", b.Humanize(), expected, res)
+		}
+	}
+}
+
+func TestNanoBalanceJSON(t *testing.T) {
+	type jsonTest struct {
+		Balance Balance `json:"balance"`
+	}
+
+	tests := map[string]Balance{
+		`{"balance":"340282366920938463463374607431768211455"}`: ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff),
+		`{"balance":"0"}`: ZeroBalance,
+	}
+
+	for s, b := range tests {
+		bytes, err := json.Marshal(jsonTest{b})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(bytes) != s {
+			t.Errorf("expected: %s, got: %s", s, bytes)
+		}
+
+		var res jsonTest
+		if err = json.Unmarshal(bytes, &res); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Balance.Equal(b) {
+			t.Errorf("expected: %s, got: %s", b, res.Balance)
+		}
+	}
+
+	// null is a no-op, as for the types of encoding/json
+	res := jsonTest{Balance: ParseBalanceInts(0, 42)}
+	if err := json.Unmarshal([]byte(`{"balance":null}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Balance != ParseBalanceInts(0, 42) {
+		t.Errorf("expected null to keep the balance, got: %s", res.Balance.RawString())
+	}
+	if err := res.Balance.UnmarshalJSON([]byte(`null`)); err != nil || res.Balance != ParseBalanceInts(0, 42) {
+		t.Errorf("expected null to keep the balance, got: %s (%v)", res.Balance.RawString(), err)
+	}
+
+	for _, s := range []string{`1000`, `340282366920938463463374607431768211455`, `1e30`, `-1`, `true`} {
+		err := json.Unmarshal([]byte(`{"balance":`+s+`}`), &res)
+		if !errors.Is(err, ErrBalanceMustBeString) {
+			t.Errorf("(%s) expected balance must be string error, got: %v", s, err)
+		}
+	}
+	for _, s := range []string{`""`, `"1.5"`, `"1e30"`, `" 1"`, `"1_000"`, `"-1"`} {
+		err := json.Unmarshal([]byte(`{"balance":`+s+`}`), &res)
+		if !errors.Is(err, ErrBadBalanceFormat) {
+			t.Errorf("(%s) expected bad balance format error, got: %v", s, err)
+		}
+	}
+}
+
+func TestNanoBalanceSQL(t *testing.T) {
+	b := ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+
+	value, err := b.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srcs := []interface{}{value, []byte(value.(string))}
+	for _, src := range srcs {
+		var res Balance
+		if err = res.Scan(src); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(b) {
+			t.Errorf("(%T) expected: %s, got: %s", src, b, res)
+		}
+	}
+
+	res := ParseBalanceInts(0, 1)
+	if err = res.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !res.Equal(ZeroBalance) {
+		t.Errorf("expected nil to scan to zero, got: %s", res)
+	}
+
+	if err = res.Scan(int64(1000)); err != nil {
+		t.Fatal(err)
+	}
+	if !res.Equal(ParseBalanceInts(0, 1000)) {
+		t.Errorf("unexpected balance: %s", res.UnitString("raw", 0))
+	}
+
+	if err = res.Scan(int64(-1)); err != ErrNegativeBalance {
+		t.Errorf("expected negative balance error, got: %v", err)
+	}
+
+	if err = res.Scan(1.5); err == nil {
+		t.Errorf("expected an error for an unsupported type")
+	}
+}
+
+func TestNanoBalanceSum(t *testing.T) {
+	sum, err := Sum(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sum.Equal(ZeroBalance) {
+		t.Fatalf("expected zero, got: %s", sum)
+	}
+
+	balances := make([]Balance, 1000)
+	for i := range balances {
+		balances[i] = ParseBalanceInts(0, 0xffffffffffffffff)
+	}
+
+	sum, err = Sum(balances)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sum.Equal(ParseBalanceInts(999, 0xffffffffffffffff-999)) {
+		t.Fatalf("unexpected sum: %s", sum.UnitString("raw", 0))
+	}
+
+	balances = []Balance{
+		ParseBalanceInts(0x8000000000000000, 0),
+		ParseBalanceInts(0x7fffffffffffffff, 0xffffffffffffffff),
+		ParseBalanceInts(0, 1),
+		ZeroBalance,
+	}
+	if _, err = Sum(balances); err != ErrBalanceOverflow {
+		t.Fatalf("expected overflow, got: %v", err)
+	}
+}
+
+func TestNanoBalanceMinMax(t *testing.T) {
+	small := ParseBalanceInts(0, 0xffffffffffffffff)
+	big := ParseBalanceInts(1, 0)
+
+	if !Min(small, big).Equal(small) || !Min(big, small).Equal(small) {
+		t.Errorf("unexpected min")
+	}
+	if !Max(small, big).Equal(big) || !Max(big, small).Equal(big) {
+		t.Errorf("unexpected max")
+	}
+	if !Min(big, big).Equal(big) || !Max(big, big).Equal(big) {
+		t.Errorf("unexpected result for equal inputs")
+	}
+	if !Min(ZeroBalance, small).Equal(ZeroBalance) || !Max(ZeroBalance, small).Equal(small) {
+		t.Errorf("unexpected result for zero")
+	}
+}
+
+func TestNanoBalanceProportionOf(t *testing.T) {
+	total := ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+
+	half, _, err := total.DivMod(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := half.PercentOf(ParseBalanceInts(0x7fffffffffffffff, 0xffffffffffffffff)).String(); s != "100" {
+		t.Errorf("expected 100, got: %s", s)
+	}
+
+	quarter := ParseBalanceInts(0, 25)
+	if s := quarter.ProportionOf(ParseBalanceInts(0, 100)).String(); s != "0.25" {
+		t.Errorf("expected 0.25, got: %s", s)
+	}
+	if s := quarter.PercentOf(ParseBalanceInts(0, 100)).String(); s != "25" {
+		t.Errorf("expected 25, got: %s", s)
+	}
+
+	tiny := ParseBalanceInts(0, 1)
+	if tiny.ProportionOf(total).IsZero() {
+		t.Errorf("a single raw was rounded to zero")
+	}
+
+	if !tiny.ProportionOf(ZeroBalance).IsZero() || !tiny.PercentOf(ZeroBalance).IsZero() {
+		t.Errorf("expected zero for a zero total")
+	}
+}
+
+func TestNanoBalanceGob(t *testing.T) {
+	balances := []Balance{
+		ZeroBalance,
+		ParseBalanceInts(0x0102030405060708, 0x090a0b0c0d0e0f10),
+		ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff),
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(balances); err != nil {
+		t.Fatal(err)
+	}
+
+	var res []Balance
+	if err := gob.NewDecoder(&buf).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res) != len(balances) {
+		t.Fatalf("expected %d balances, got: %d", len(balances), len(res))
+	}
+	for i := range balances {
+		if !res[i].Equal(balances[i]) {
+			t.Errorf("expected: %s, got: %s", balances[i], res[i])
+		}
+	}
+}
+
+func TestNanoBalanceBytesOrder(t *testing.T) {
+	b := ParseBalanceInts(0x0102030405060708, 0x090a0b0c0d0e0f10)
+	be := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	le := []byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}
+
+	if res := b.BytesBE(); !bytes.Equal(res, be) || !bytes.Equal(b.Bytes(binary.BigEndian), be) {
+		t.Errorf("unexpected big-endian bytes: %x", res)
+	}
+	if res := b.BytesLE(); !bytes.Equal(res, le) || !bytes.Equal(b.Bytes(binary.LittleEndian), le) {
+		t.Errorf("unexpected little-endian bytes: %x", res)
+	}
+	if res := ZeroBalance.BytesBE(); !bytes.Equal(res, make([]byte, BalanceSize)) {
+		t.Errorf("unexpected bytes of zero balance: %x", res)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic for a nil byte order")
+		}
+	}()
+	b.Bytes(nil)
+}
+
+func TestNanoBalanceUnmarshalBinarySize(t *testing.T) {
+	var b Balance
+	for _, size := range []int{0, 12, BalanceSize + 1} {
+		err := b.UnmarshalBinary(make([]byte, size))
+		if !errors.Is(err, ErrBadBalanceSize) {
+			t.Fatalf("(%d) expected bad balance size error, got: %v", size, err)
+		}
+		if !strings.Contains(err.Error(), fmt.Sprintf("got %d bytes", size)) {
+			t.Errorf("(%d) size missing from error: %s", size, err)
+		}
+
+		if err = b.GobDecode(make([]byte, size)); !errors.Is(err, ErrBadBalanceSize) {
+			t.Errorf("(%d) expected bad balance size error, got: %v", size, err)
+		}
+	}
+}
+
+func TestNanoBalanceCmp(t *testing.T) {
+	small := ParseBalanceInts(0, 0xffffffffffffffff)
+	big := ParseBalanceInts(1, 0)
+
+	if small.Cmp(big) != -1 || big.Cmp(small) != 1 || big.Cmp(big) != 0 {
+		t.Fatal("unexpected comparison result")
+	}
+
+	for _, pair := range [][2]Balance{{small, big}, {big, small}, {big, big}} {
+		if pair[0].Cmp(pair[1]) != pair[0].BigInt().Cmp(pair[1].BigInt()) {
+			t.Errorf("Cmp does not match big.Int.Cmp for %s and %s", pair[0], pair[1])
+		}
+	}
+}
+
+func TestNanoBalancePredicates(t *testing.T) {
+	type predicateTest struct {
+		a, b             Balance
+		gt, gte, lt, lte bool
+	}
+
+	small := ParseBalanceInts(0, 0xffffffffffffffff)
+	big := ParseBalanceInts(1, 0)
+	tests := []predicateTest{
+		{big, big, false, true, false, true},
+		{big, small, true, true, false, false},
+		{small, big, false, false, true, true},
+	}
+
+	for _, test := range tests {
+		if res := test.a.GreaterThan(test.b); res != test.gt {
+			t.Errorf("(%s > %s) expected: %t", test.a.RawString(), test.b.RawString(), test.gt)
+		}
+		if res := test.a.GreaterThanOrEqual(test.b); res != test.gte {
+			t.Errorf("(%s >= %s) expected: %t", test.a.RawString(), test.b.RawString(), test.gte)
+		}
+		if res := test.a.LessThan(test.b); res != test.lt {
+			t.Errorf("(%s < %s) expected: %t", test.a.RawString(), test.b.RawString(), test.lt)
+		}
+		if res := test.a.LessThanOrEqual(test.b); res != test.lte {
+			t.Errorf("(%s <= %s) expected: %t", test.a.RawString(), test.b.RawString(), test.lte)
+		}
+	}
+
+	if !ZeroBalance.IsZero() || !ParseBalanceInts(0, 0).IsZero() {
+		t.Errorf("zero balance is not zero")
+	}
+	if small.IsZero() || big.IsZero() {
+		t.Errorf("non-zero balance is zero")
+	}
+}
+
+func TestNanoBalanceDelta(t *testing.T) {
+	type deltaTest struct {
+		prev, cur Balance
+		amount    Balance
+		increased bool
+	}
+
+	tests := []deltaTest{
+		{ParseBalanceInts(0, 40), ParseBalanceInts(0, 100), ParseBalanceInts(0, 60), true},
+		{ParseBalanceInts(0, 100), ParseBalanceInts(0, 40), ParseBalanceInts(0, 60), false},
+		{ParseBalanceInts(1, 0), ParseBalanceInts(1, 0), ZeroBalance, false},
+		{ZeroBalance, GenesisBalance, GenesisBalance, true},
+		{GenesisBalance, ZeroBalance, GenesisBalance, false},
+	}
+
+	for _, test := range tests {
+		amount, increased := BalanceDelta(test.prev, test.cur)
+		if amount != test.amount || increased != test.increased {
+			t.Errorf("(%s -> %s) expected: %s %t, got: %s %t", test.prev.RawString(), test.cur.RawString(),
+				test.amount.RawString(), test.increased, amount.RawString(), increased)
+		}
+	}
+}
+
+func TestNanoBalanceSplitProportional(t *testing.T) {
+	type splitTest struct {
+		balance Balance
+		weights []uint64
+		parts   []Balance
+	}
+
+	tests := []splitTest{
+		{ParseBalanceInts(0, 100), []uint64{1, 1}, []Balance{ParseBalanceInts(0, 50), ParseBalanceInts(0, 50)}},
+		{ParseBalanceInts(0, 100), []uint64{1, 1, 1}, []Balance{ParseBalanceInts(0, 34), ParseBalanceInts(0, 33), ParseBalanceInts(0, 33)}},
+		{ParseBalanceInts(0, 100), []uint64{1, 2, 3}, []Balance{ParseBalanceInts(0, 17), ParseBalanceInts(0, 33), ParseBalanceInts(0, 50)}},
+		{ParseBalanceInts(0, 10), []uint64{0, 7, 0, 3}, []Balance{ZeroBalance, ParseBalanceInts(0, 7), ZeroBalance, ParseBalanceInts(0, 3)}},
+		{ParseBalanceInts(0, 1), []uint64{5, 5}, []Balance{ParseBalanceInts(0, 1), ZeroBalance}},
+		{ZeroBalance, []uint64{3, 4}, []Balance{ZeroBalance, ZeroBalance}},
+	}
+
+	for _, test := range tests {
+		parts, err := test.balance.SplitProportional(test.weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(parts, test.parts) {
+			t.Errorf("(%v) expected: %v, got: %v", test.weights, test.parts, parts)
+		}
+	}
+
+	// the parts always sum up to the original balance
+	weights := [][]uint64{
+		{1},
+		{1, 2, 3, 4, 5, 6, 7},
+		{math.MaxUint64, math.MaxUint64, 1},
+		{999999999, 1, 333, 0, 12345678901234},
+	}
+	for _, balance := range []Balance{ParseBalanceInts(0, 7), MaxSupply, GenesisBalance} {
+		for _, w := range weights {
+			parts, err := balance.SplitProportional(w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sum, err := Sum(parts); err != nil || sum != balance {
+				t.Errorf("(%s %v) parts sum up to: %s (%v)", balance.RawString(), w, sum.RawString(), err)
+			}
+		}
+	}
+
+	for _, w := range [][]uint64{nil, {}, {0, 0}} {
+		if _, err := MaxSupply.SplitProportional(w); err != ErrZeroTotalWeight {
+			t.Errorf("(%v) expected zero total weight error, got: %v", w, err)
+		}
+	}
+}
+
+func TestNanoBalanceSplitN(t *testing.T) {
+	shares, err := ParseBalanceInts(0, 10).SplitN(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Balance{ParseBalanceInts(0, 4), ParseBalanceInts(0, 3), ParseBalanceInts(0, 3)}
+	if !reflect.DeepEqual(shares, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, shares)
+	}
+
+	for _, balance := range []Balance{ZeroBalance, ParseBalanceInts(0, 7), MaxSupply, GenesisBalance} {
+		for _, n := range []int{1, 2, 3, 7, 1000} {
+			shares, err := balance.SplitN(n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(shares) != n {
+				t.Fatalf("expected %d shares, got: %d", n, len(shares))
+			}
+			if sum, err := Sum(shares); err != nil || sum != balance {
+				t.Errorf("(%s / %d) shares sum up to: %s (%v)", balance.RawString(), n, sum.RawString(), err)
+			}
+
+			// the shares differ by at most one raw, with the larger ones first
+			_, remainder, _ := balance.DivMod(uint64(n))
+			for i := 1; i < n; i++ {
+				diff, _ := BalanceDelta(shares[i], shares[i-1])
+				if diff.Cmp(ParseBalanceInts(0, 1)) > 0 || shares[i].GreaterThan(shares[i-1]) {
+					t.Fatalf("(%s / %d) uneven shares: %v", balance.RawString(), n, shares)
+				}
+				if !diff.IsZero() && uint64(i) != remainder {
+					t.Fatalf("(%s / %d) remainder not given to the first shares", balance.RawString(), n)
+				}
+			}
+		}
+	}
+
+	for _, n := range []int{0, -1} {
+		if _, err := MaxSupply.SplitN(n); err != ErrBadShareCount {
+			t.Errorf("(%d) expected bad share count error, got: %v", n, err)
+		}
+	}
+}
+
+func TestNanoBalanceAccumulator(t *testing.T) {
+	var acc BalanceAccumulator
+	acc.Add(ParseBalanceInts(0, 100))
+	acc.Add(ParseBalanceInts(1, 0))
+	acc.Sub(ParseBalanceInts(0, 40))
+	if err := acc.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if total := acc.Total(); total != ParseBalanceInts(1, 60) {
+		t.Fatalf("unexpected total: %s", total.RawString())
+	}
+
+	acc = BalanceAccumulator{}
+	acc.Add(ParseBalanceInts(0, 10))
+	acc.Sub(ParseBalanceInts(0, 11))
+	if acc.Err() != ErrBalanceUnderflow {
+		t.Fatalf("expected underflow, got: %v", acc.Err())
+	}
+
+	// the error is latched
+	acc.Add(ParseBalanceInts(0, 100))
+	acc.Add(GenesisBalance)
+	acc.Add(GenesisBalance)
+	if acc.Err() != ErrBalanceUnderflow || !acc.Total().IsZero() {
+		t.Fatalf("expected latched underflow, got: %v (%s)", acc.Err(), acc.Total().RawString())
+	}
+
+	acc = BalanceAccumulator{}
+	acc.Add(GenesisBalance)
+	acc.Add(ParseBalanceInts(0, 1))
+	acc.Sub(ParseBalanceInts(0, 1))
+	if acc.Err() != ErrBalanceOverflow {
+		t.Fatalf("expected overflow, got: %v", acc.Err())
+	}
+}
+
+func TestNanoBalanceIsDust(t *testing.T) {
+	threshold, err := ParseBalance("0.000001", "Nano")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if threshold != DefaultDustThreshold {
+		t.Fatalf("unexpected default dust threshold: %s", DefaultDustThreshold.RawString())
+	}
+
+	one := ParseBalanceInts(0, 1)
+	tests := map[Balance]bool{
+		ZeroBalance:        true,
+		threshold.Sub(one): true,
+		threshold:          false,
+		threshold.Add(one): false,
+		MaxSupply:          false,
+	}
+	for b, expected := range tests {
+		if res := b.IsDust(threshold); res != expected {
+			t.Errorf("(%s) expected: %t, got: %t", b.RawString(), expected, res)
+		}
+	}
+
+	if ZeroBalance.IsDust(ZeroBalance) {
+		t.Errorf("nothing is dust with a zero threshold")
+	}
+}
+
+func ExampleBalance_Cmp() {
+	balances := []Balance{
+		ParseBalanceInts(0, 300),
+		ParseBalanceInts(0, 100),
+		ParseBalanceInts(0, 200),
+	}
+
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].Cmp(balances[j]) < 0
+	})
+
+	for _, b := range balances {
+		fmt.Println(b.UnitString("raw", 0))
+	}
+	// Output:
+	// 100
+	// 200
+	// 300
+}
+
+func TestNanoBalanceParseStrip(t *testing.T) {
+	expected := ParseBalanceInts(0, 1000000)
+	for _, s := range []string{" 1_000_000 ", "1000000\n", "\t1_000000", "1_000_000.0"} {
+		b, err := ParseBalance(s, "raw")
+		if err != nil {
+			t.Errorf("(%q) %s", s, err)
+			continue
+		}
+		if !b.Equal(expected) {
+			t.Errorf("(%q) expected: %s, got: %s", s, expected.UnitString("raw", 0), b.UnitString("raw", 0))
+		}
+	}
+
+	for _, s := range []string{"_1000", "1000_", "1__000", "1_.0", "1 000", "1,000", "", "  "} {
+		if _, err := ParseBalance(s, "raw"); err == nil {
+			t.Errorf("(%q) expected an error", s)
+		}
+	}
+}
+
+func TestNanoBalanceAddSubAll(t *testing.T) {
+	one := ParseBalanceInts(0, 1)
+	max := ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+
+	sum, err := AddAll()
+	if err != nil || !sum.Equal(ZeroBalance) {
+		t.Fatalf("expected zero, got: %s (%v)", sum, err)
+	}
+
+	sum, err = AddAll(one, one, one)
+	if err != nil || !sum.Equal(ParseBalanceInts(0, 3)) {
+		t.Fatalf("expected 3 raw, got: %s (%v)", sum.UnitString("raw", 0), err)
+	}
+
+	_, err = AddAll(one, ZeroBalance, max, one)
+	var opErr *BalanceOpError
+	if !errors.As(err, &opErr) || opErr.Index != 2 || !errors.Is(err, ErrBalanceOverflow) {
+		t.Fatalf("expected overflow at index 2, got: %v", err)
+	}
+
+	res, err := SubAll(max)
+	if err != nil || !res.Equal(max) {
+		t.Fatalf("expected max, got: %s (%v)", res, err)
+	}
+
+	res, err = SubAll(ParseBalanceInts(0, 3), one, one)
+	if err != nil || !res.Equal(one) {
+		t.Fatalf("expected 1 raw, got: %s (%v)", res.UnitString("raw", 0), err)
+	}
+
+	_, err = SubAll(ParseBalanceInts(0, 2), one, one, one)
+	if !errors.As(err, &opErr) || opErr.Index != 2 || !errors.Is(err, ErrBalanceUnderflow) {
+		t.Fatalf("expected underflow at index 2, got: %v", err)
+	}
+}
+
+func TestNanoBalanceSupply(t *testing.T) {
+	if s := MaxSupply.String(); s != "133248297.920938463463374607431768211455" {
+		t.Errorf("unexpected max supply: %s", s)
+	}
+	if s := MaxSupply.UnitString("raw", 0); s != "133248297920938463463374607431768211455" {
+		t.Errorf("unexpected max supply: %s", s)
+	}
+	if s := GenesisBalance.UnitString("raw", 0); s != "340282366920938463463374607431768211455" {
+		t.Errorf("unexpected genesis balance: %s", s)
+	}
+}
+
+func TestNanoBalanceTextUnit(t *testing.T) {
+	balances := []Balance{
+		ZeroBalance,
+		ParseBalanceInts(0, 1),
+		ParseBalanceInts(0x0102030405060708, 0x090a0b0c0d0e0f10),
+		ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff),
+	}
+
+	for _, b := range balances {
+		text, err := b.MarshalTextUnit("raw", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != b.BigInt().String() {
+			t.Errorf("expected: %s, got: %s", b.BigInt(), text)
+		}
+
+		var res Balance
+		if err = res.UnmarshalTextUnit(text, "raw"); err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(b) {
+			t.Errorf("expected: %s, got: %s", b, res)
+		}
+	}
+
+	text, err := MaxSupply.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "133248297.920938463463374607431768211455" {
+		t.Errorf("unexpected default text encoding: %s", text)
+	}
+
+	if _, err = MaxSupply.MarshalTextUnit("bogus", 0); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("expected unknown unit error, got: %v", err)
+	}
+}
+
+func TestNanoBalanceHex(t *testing.T) {
+	tests := map[string]Balance{
+		"00000000000000000000000000000000": ZeroBalance,
+		"00000000000000000000000000000001": ParseBalanceInts(0, 1),
+		"0000000000000001000000000000000f": ParseBalanceInts(1, 15),
+		"ffffffffffffffffffffffffffffffff": ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff),
+	}
+
+	for s, b := range tests {
+		if res := b.Hex(); res != s {
+			t.Errorf("expected: %s, got: %s", s, res)
+		}
+
+		res, err := ParseBalanceHex(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(b) {
+			t.Errorf("(%s) expected: %s, got: %s", s, b, res)
+		}
+	}
+
+	for _, s := range []string{"", "1", "0000000000000000000000000000001", "000000000000000000000000000000001"} {
+		if _, err := ParseBalanceHex(s); err != ErrBadBalanceHex {
+			t.Errorf("(%q) expected bad hex error, got: %v", s, err)
+		}
+	}
+
+	if _, err := ParseBalanceHex("0000000000000000000000000000000g"); err == nil {
+		t.Errorf("expected an error for an invalid hex character")
+	}
+}
+
+func TestNanoBalanceFromBigInt(t *testing.T) {
+	max := ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+
+	for _, b := range []Balance{ZeroBalance, ParseBalanceInts(0, 1), MaxSupply, max} {
+		res, err := NewBalanceFromBigInt(b.BigInt())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(b) {
+			t.Errorf("expected: %s, got: %s", b, res)
+		}
+	}
+
+	if _, err := NewBalanceFromBigInt(big.NewInt(-1)); err != ErrNegativeBalance {
+		t.Errorf("expected negative balance error, got: %v", err)
+	}
+
+	tooLarge := new(big.Int).Add(max.BigInt(), big.NewInt(1))
+	if _, err := NewBalanceFromBigInt(tooLarge); err != ErrBalanceOverflow {
+		t.Errorf("expected overflow, got: %v", err)
+	}
+}
+
+func TestNanoBalanceMulDecimal(t *testing.T) {
+	b := ParseBalanceInts(0, 123456789)
+
+	tests := map[string]Balance{
+		"0.001": ParseBalanceInts(0, 123456),
+		"1.0":   b,
+		"0.0":   ZeroBalance,
+		"2.5":   ParseBalanceInts(0, 308641972),
+	}
+
+	for s, expected := range tests {
+		res, err := b.MulDecimal(decimal.RequireFromString(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(expected) {
+			t.Errorf("(%s) expected: %s, got: %s", s, expected.UnitString("raw", 0), res.UnitString("raw", 0))
+		}
+	}
+
+	max := ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff)
+	if _, err := max.MulDecimal(decimal.RequireFromString("1.0000001")); err != ErrBalanceOverflow {
+		t.Errorf("expected overflow, got: %v", err)
+	}
+
+	if _, err := b.MulDecimal(decimal.RequireFromString("-0.5")); err != ErrNegativeBalance {
+		t.Errorf("expected negative balance error, got: %v", err)
+	}
+}
+
+func TestNanoBalanceUnitStringFixed(t *testing.T) {
+	type fixedTest struct {
+		balance   string
+		precision int32
+		trimmed   string
+		fixed     string
+	}
+
+	tests := []fixedTest{
+		{"0", 6, "0", "0.000000"},
+		{"1.5", 2, "1.5", "1.50"},
+		{"1.5", 0, "1", "1"},
+		{"1.23456789", 4, "1.2345", "1.2345"},
+		{"340282366.920938463463374607431768211455", 3, "340282366.92", "340282366.920"},
+	}
+
+	for _, test := range tests {
+		b, err := ParseBalance(test.balance, "Nano")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res := b.UnitString("Nano", test.precision); res != test.trimmed {
+			t.Errorf("expected: %s, got: %s", test.trimmed, res)
+		}
+		if res := b.UnitStringFixed("Nano", test.precision); res != test.fixed {
+			t.Errorf("expected: %s, got: %s", test.fixed, res)
+		}
+	}
+}
+
+func TestNanoBalanceHalves(t *testing.T) {
+	tests := []struct {
+		hi, lo uint64
+	}{
+		{0, 0},
+		{0, 1},
+		{1, 0},
+		{0x36, 0x35c9adc5dea00000},
+		{0xffffffffffffffff, 0xffffffffffffffff},
+	}
+
+	for _, test := range tests {
+		balance := ParseBalanceInts(test.hi, test.lo)
+		if balance.Hi != test.hi || balance.Lo != test.lo {
+			t.Errorf("expected halves %x, %x, got: %x, %x", test.hi, test.lo, balance.Hi, balance.Lo)
+		}
+	}
+
+	// 2^64 raw has a single bit set in the most significant half
+	balance, err := ParseBalance("18446744073709551616", "raw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Hi != 1 || balance.Lo != 0 {
+		t.Errorf("expected halves 1, 0, got: %x, %x", balance.Hi, balance.Lo)
+	}
+}
+
+func TestNanoBalanceInts(t *testing.T) {
+	balances := []Balance{ZeroBalance, ParseBalanceInts(0, 1), ParseBalanceInts(1, 0), MaxSupply, GenesisBalance}
+	for _, b := range balances {
+		if ParseBalanceInts(b.Ints()) != b {
+			t.Errorf("balance %s does not round-trip", b.UnitString("raw", 0))
+		}
+	}
+
+	hi, lo := ParseBalanceInts(0x36, 0x35c9adc5dea00000).Ints()
+	if hi != 0x36 || lo != 0x35c9adc5dea00000 {
+		t.Errorf("expected halves 36, 35c9adc5dea00000, got: %x, %x", hi, lo)
+	}
+}
+
+func TestNanoBalanceRawString(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	balances := []Balance{ZeroBalance, ParseBalanceInts(0, 1), MaxSupply, GenesisBalance}
+	for i := 0; i < 1000; i++ {
+		balances = append(balances, ParseBalanceInts(rng.Uint64(), rng.Uint64()))
+	}
+
+	for _, b := range balances {
+		s := b.RawString()
+		if s != b.BigInt().String() {
+			t.Fatalf("expected: %s, got: %s", b.BigInt(), s)
+		}
+
+		parsed, err := ParseBalance(s, "raw")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != b {
+			t.Fatalf("balance %s does not round-trip, got: %s", s, parsed.RawString())
+		}
+	}
+}
+
+func FuzzBalanceRoundTrip(f *testing.F) {
+	f.Add(make([]byte, BalanceSize))
+	f.Add(bytes.Repeat([]byte{0xff}, BalanceSize))
+	f.Add(MaxSupply.Bytes(binary.BigEndian))
+	f.Add([]byte{1})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) > BalanceSize {
+			data = data[:BalanceSize]
+		}
+		b, err := NewBalanceFromBigInt(new(big.Int).SetBytes(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, s := range []string{b.RawString(), b.UnitString("raw", 0)} {
+			parsed, err := ParseBalance(s, "raw")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed != b {
+				t.Fatalf("balance %s does not round-trip, got: %s", s, parsed.RawString())
+			}
+		}
+
+		// units other than raw produce negative exponents when parsing
+		for unit := range units {
+			s := b.UnitString(unit, BalanceMaxPrecision)
+			parsed, err := ParseBalance(s, unit)
+			if err != nil {
+				t.Fatalf("(%s %s) %s", s, unit, err)
+			}
+			if parsed != b {
+				t.Fatalf("balance %s %s does not round-trip, got: %s", s, unit, parsed.RawString())
+			}
+		}
+	})
+}
+
+func TestNanoDecodeBalances(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	balances := []Balance{ZeroBalance, GenesisBalance, MaxSupply, ParseBalanceInts(0x0102030405060708, 0x090a0b0c0d0e0f10)}
+	for i := 0; i < 100; i++ {
+		balances = append(balances, ParseBalanceInts(r.Uint64(), r.Uint64()))
+	}
+
+	var data []byte
+	for _, b := range balances {
+		record, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, record...)
+	}
+
+	res, err := DecodeBalances(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != len(balances) {
+		t.Fatalf("expected %d balances, got: %d", len(balances), len(res))
+	}
+	for i := range balances {
+		// compare against the single record little endian path
+		var single Balance
+		if err = single.GobDecode(data[i*BalanceSize : (i+1)*BalanceSize]); err != nil {
+			t.Fatal(err)
+		}
+		if res[i] != balances[i] || res[i] != single {
+			t.Errorf("(%d) expected: %s, got: %s", i, balances[i].RawString(), res[i].RawString())
+		}
+	}
+
+	if res, err = DecodeBalances(nil); err != nil || len(res) != 0 {
+		t.Errorf("expected no balances, got: %v (%v)", res, err)
+	}
+	for _, size := range []int{1, BalanceSize - 1, BalanceSize + 1, 3*BalanceSize - 2} {
+		if _, err = DecodeBalances(make([]byte, size)); !errors.Is(err, ErrBadBalanceSize) {
+			t.Errorf("(%d) expected bad balance size error, got: %v", size, err)
+		}
+	}
+}
+
+func BenchmarkDecodeBalances(b *testing.B) {
+	data := make([]byte, 100000*BalanceSize)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeBalances(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// failingWriter fails after the given number of writes and counts the calls.
+type failingWriter struct {
+	writes int
+	calls  int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.calls++
+	if w.writes == 0 {
+		return 0, io.ErrShortWrite
+	}
+	w.writes--
+	return len(p), nil
+}
+
+func TestNanoEncodeBalances(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	balances := []Balance{ZeroBalance, GenesisBalance, MaxSupply}
+	for i := 0; i < 100; i++ {
+		balances = append(balances, ParseBalanceInts(r.Uint64(), r.Uint64()))
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeBalances(&buf, balances); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != len(balances)*BalanceSize {
+		t.Fatalf("unexpected size: %d", buf.Len())
+	}
+
+	for i, b := range balances {
+		record, _ := b.MarshalBinary()
+		if !bytes.Equal(buf.Bytes()[i*BalanceSize:(i+1)*BalanceSize], record) {
+			t.Fatalf("(%d) encoding does not match MarshalBinary", i)
+		}
+	}
+
+	res, err := DecodeBalances(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res, balances) {
+		t.Fatalf("balances do not round-trip")
+	}
+
+	w := &failingWriter{writes: 2}
+	if err = EncodeBalances(w, balances); err != io.ErrShortWrite {
+		t.Fatalf("expected short write error, got: %v", err)
+	}
+	if w.calls != 3 {
+		t.Fatalf("expected the encoding to stop at the failed write, got %d writes", w.calls)
+	}
+}
+
+func BenchmarkEncodeBalances(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	balances := make([]Balance, 100000)
+	for i := range balances {
+		balances[i] = ParseBalanceInts(r.Uint64(), r.Uint64())
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(balances) * BalanceSize)
+	b.SetBytes(int64(len(balances) * BalanceSize))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := EncodeBalances(&buf, balances); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
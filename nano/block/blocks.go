@@ -7,6 +7,7 @@ import (
 	"errors"
 
 	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/crypto/ed25519"
 	"littleriver.cc/go-nano/nano/internal/util"
 )
 
@@ -23,6 +24,11 @@ const (
 var (
 	ErrBadBlockType = errors.New("bad block type")
 	ErrNotABlock    = errors.New("block type is not_a_block")
+	ErrKeyMismatch  = errors.New("private key does not belong to the block account")
+
+	ErrZeroAccount          = errors.New("block account is zero")
+	ErrBalanceExceedsSupply = errors.New("block balance exceeds the supply")
+	ErrOpenWithoutLink      = errors.New("open block has no link")
 
 	blockNames = map[byte]string{
 		idBlockInvalid:   "invalid",
@@ -45,53 +51,65 @@ const (
 	blockSizeState   = blockSizeCommon + HashSize*2 + nano.AddressSize*2 + nano.BalanceSize
 )
 
+// Block is implemented by all block types.
 type Block interface {
 	encoding.BinaryMarshaler
 	encoding.BinaryUnmarshaler
 	Hash() Hash
 	Root() Hash
+	Signature() Signature
 	Size() int
 	ID() byte
 	Valid(threshold uint64) bool
 }
 
+// OpenBlock is the legacy block type that opens an account by receiving its
+// first pending send. Its hash covers the source, representative and account.
 type OpenBlock struct {
 	SourceHash     Hash         `json:"source"`
 	Representative nano.Address `json:"representative"`
 	Address        nano.Address `json:"address"`
-	Signature      Signature    `json:"signature"`
+	Sig            Signature    `json:"signature"`
 	Work           Work         `json:"work"`
 }
 
+// SendBlock is the legacy block type that sends funds to another account. Its
+// hash covers the previous hash, destination and the balance after the send.
 type SendBlock struct {
 	PreviousHash Hash         `json:"previous"`
 	Destination  nano.Address `json:"destination"`
 	Balance      nano.Balance `json:"balance"`
-	Signature    Signature    `json:"signature"`
+	Sig          Signature    `json:"signature"`
 	Work         Work         `json:"work"`
 }
 
+// ReceiveBlock is the legacy block type that receives a pending send. Its hash
+// covers the previous hash and the hash of the send block.
 type ReceiveBlock struct {
 	PreviousHash Hash      `json:"previous"`
 	SourceHash   Hash      `json:"source"`
-	Signature    Signature `json:"signature"`
+	Sig          Signature `json:"signature"`
 	Work         Work      `json:"work"`
 }
 
+// ChangeBlock is the legacy block type that changes the representative of an
+// account. Its hash covers the previous hash and the new representative.
 type ChangeBlock struct {
 	PreviousHash   Hash         `json:"previous"`
 	Representative nano.Address `json:"representative"`
-	Signature      Signature    `json:"signature"`
+	Sig            Signature    `json:"signature"`
 	Work           Work         `json:"work"`
 }
 
+// StateBlock is the universal block type that replaced the legacy blocks. It
+// carries the full state of the account chain after the block is applied.
 type StateBlock struct {
 	Address        nano.Address `json:"address"`
 	PreviousHash   Hash         `json:"previous"`
 	Representative nano.Address `json:"representative"`
 	Balance        nano.Balance `json:"balance"`
 	Link           Hash         `json:"link"`
-	Signature      Signature    `json:"signature"`
+	Sig            Signature    `json:"signature"`
 	Work           Work         `json:"work"`
 }
 
@@ -172,7 +190,7 @@ func (b *OpenBlock) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
-	commonBytes, err := marshalCommon(b.Signature, b.Work, binary.LittleEndian)
+	commonBytes, err := marshalCommon(b.Sig, b.Work, binary.LittleEndian)
 	if err != nil {
 		return nil, err
 	}
@@ -205,17 +223,22 @@ func (b *OpenBlock) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
-	return unmarshalCommon(commonBytes, binary.LittleEndian, &b.Signature, &b.Work)
+	return unmarshalCommon(commonBytes, binary.LittleEndian, &b.Sig, &b.Work)
 }
 
 func (b *OpenBlock) Hash() Hash {
-	return hashBytes(b.SourceHash[:], b.Representative[:], b.Address[:])
+	return Hash256(b.SourceHash[:], b.Representative[:], b.Address[:])
 }
 
 func (b *OpenBlock) Root() Hash {
 	return b.SourceHash
 }
 
+// Signature returns the signature of the block.
+func (b *OpenBlock) Signature() Signature {
+	return b.Sig
+}
+
 func (b *OpenBlock) Size() int {
 	return blockSizeOpen
 }
@@ -245,7 +268,7 @@ func (b *SendBlock) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
-	commonBytes, err := marshalCommon(b.Signature, b.Work, binary.LittleEndian)
+	commonBytes, err := marshalCommon(b.Sig, b.Work, binary.LittleEndian)
 	if err != nil {
 		return nil, err
 	}
@@ -282,17 +305,22 @@ func (b *SendBlock) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
-	return unmarshalCommon(commonBytes, binary.LittleEndian, &b.Signature, &b.Work)
+	return unmarshalCommon(commonBytes, binary.LittleEndian, &b.Sig, &b.Work)
 }
 
 func (b *SendBlock) Hash() Hash {
-	return hashBytes(b.PreviousHash[:], b.Destination[:], b.Balance.Bytes(binary.BigEndian))
+	return Hash256(b.PreviousHash[:], b.Destination[:], b.Balance.Bytes(binary.BigEndian))
 }
 
 func (b *SendBlock) Root() Hash {
 	return b.PreviousHash
 }
 
+// Signature returns the signature of the block.
+func (b *SendBlock) Signature() Signature {
+	return b.Sig
+}
+
 func (b *SendBlock) Size() int {
 	return blockSizeSend
 }
@@ -318,7 +346,7 @@ func (b *ReceiveBlock) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
-	commonBytes, err := marshalCommon(b.Signature, b.Work, binary.LittleEndian)
+	commonBytes, err := marshalCommon(b.Sig, b.Work, binary.LittleEndian)
 	if err != nil {
 		return nil, err
 	}
@@ -347,17 +375,22 @@ func (b *ReceiveBlock) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
-	return unmarshalCommon(commonBytes, binary.LittleEndian, &b.Signature, &b.Work)
+	return unmarshalCommon(commonBytes, binary.LittleEndian, &b.Sig, &b.Work)
 }
 
 func (b *ReceiveBlock) Hash() Hash {
-	return hashBytes(b.PreviousHash[:], b.SourceHash[:])
+	return Hash256(b.PreviousHash[:], b.SourceHash[:])
 }
 
 func (b *ReceiveBlock) Root() Hash {
 	return b.PreviousHash
 }
 
+// Signature returns the signature of the block.
+func (b *ReceiveBlock) Signature() Signature {
+	return b.Sig
+}
+
 func (b *ReceiveBlock) Size() int {
 	return blockSizeReceive
 }
@@ -383,7 +416,7 @@ func (b *ChangeBlock) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
-	commonBytes, err := marshalCommon(b.Signature, b.Work, binary.LittleEndian)
+	commonBytes, err := marshalCommon(b.Sig, b.Work, binary.LittleEndian)
 	if err != nil {
 		return nil, err
 	}
@@ -412,17 +445,22 @@ func (b *ChangeBlock) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
-	return unmarshalCommon(commonBytes, binary.LittleEndian, &b.Signature, &b.Work)
+	return unmarshalCommon(commonBytes, binary.LittleEndian, &b.Sig, &b.Work)
 }
 
 func (b *ChangeBlock) Hash() Hash {
-	return hashBytes(b.PreviousHash[:], b.Representative[:])
+	return Hash256(b.PreviousHash[:], b.Representative[:])
 }
 
 func (b *ChangeBlock) Root() Hash {
 	return b.PreviousHash
 }
 
+// Signature returns the signature of the block.
+func (b *ChangeBlock) Signature() Signature {
+	return b.Sig
+}
+
 func (b *ChangeBlock) Size() int {
 	return blockSizeChange
 }
@@ -460,7 +498,7 @@ func (b *StateBlock) MarshalBinary() ([]byte, error) {
 		return nil, err
 	}
 
-	commonBytes, err := marshalCommon(b.Signature, b.Work, binary.BigEndian)
+	commonBytes, err := marshalCommon(b.Sig, b.Work, binary.BigEndian)
 	if err != nil {
 		return nil, err
 	}
@@ -505,21 +543,31 @@ func (b *StateBlock) UnmarshalBinary(data []byte) error {
 		return err
 	}
 
-	return unmarshalCommon(commonBytes, binary.BigEndian, &b.Signature, &b.Work)
+	return unmarshalCommon(commonBytes, binary.BigEndian, &b.Sig, &b.Work)
 }
 
+// Hash returns the blake2b-256 hash of the block contents. Unlike the legacy
+// blocks, the contents are prefixed with a 32 byte preamble holding the block
+// type, as is done by the reference node.
 func (b *StateBlock) Hash() Hash {
 	var preamble [preambleSize]byte
 	preamble[len(preamble)-1] = idBlockState
-	return hashBytes(preamble[:], b.Address[:], b.PreviousHash[:], b.Representative[:], b.Balance.Bytes(binary.BigEndian), b.Link[:])
+	return Hash256(preamble[:], b.Address[:], b.PreviousHash[:], b.Representative[:], b.Balance.Bytes(binary.BigEndian), b.Link[:])
 }
 
+// Root returns the hash that work is generated over. This is the previous hash
+// for existing accounts and the public key of the account for the first block.
 func (b *StateBlock) Root() Hash {
 	if !b.IsOpen() {
 		return b.PreviousHash
 	}
 
-	return b.Link
+	return Hash(b.Address)
+}
+
+// Signature returns the signature of the block.
+func (b *StateBlock) Signature() Signature {
+	return b.Sig
 }
 
 func (b *StateBlock) Size() int {
@@ -531,13 +579,57 @@ func (b *StateBlock) ID() byte {
 }
 
 func (b *StateBlock) Valid(threshold uint64) bool {
-	if !b.IsOpen() {
-		return b.Work.Valid(b.PreviousHash, threshold)
+	return b.Work.Valid(b.Root(), threshold)
+}
+
+// Sign signs the hash of the block with the given private key and stores the
+// result in the Sig field. It returns an error if the key does not belong
+// to the account of the block.
+func (b *StateBlock) Sign(key ed25519.PrivateKey) error {
+	if len(key) != ed25519.PrivateKeySize {
+		return ErrKeyMismatch
 	}
 
-	return b.Work.Valid(Hash(b.Address), threshold)
+	address, err := nano.AddressFromPublicKey(key.PublicKey())
+	if err != nil || address != b.Address {
+		return ErrKeyMismatch
+	}
+
+	hash := b.Hash()
+	copy(b.Sig[:], ed25519.Sign(key, hash[:]))
+	return nil
+}
+
+// VerifySignature reports whether the Sig field holds a valid signature of
+// the block hash by the account of the block.
+func (b *StateBlock) VerifySignature() bool {
+	hash := b.Hash()
+	return b.Address.Verify(hash[:], b.Sig[:])
 }
 
 func (b *StateBlock) IsOpen() bool {
 	return b.PreviousHash.IsZero()
 }
+
+// Validate checks that the fields of the block are consistent before it is
+// signed or published. It returns ErrZeroAccount if the account is the zero
+// address, ErrBalanceExceedsSupply if the balance is bigger than
+// nano.MaxSupply and ErrOpenWithoutLink for an open block without a source.
+//
+// The account is a nano.Address, which can only be created from a key of the
+// right size or a string with a valid checksum, so the zero address is the
+// only invalid account left to detect. Likewise, PreviousHash and Link are
+// fixed-size arrays whose length is always correct, so there are no errors for
+// bad hash lengths.
+func (b *StateBlock) Validate() error {
+	switch {
+	case b.Address == nano.Address{}:
+		return ErrZeroAccount
+	case b.Balance.GreaterThan(nano.MaxSupply):
+		return ErrBalanceExceedsSupply
+	case b.IsOpen() && b.Link.IsZero():
+		return ErrOpenWithoutLink
+	default:
+		return nil
+	}
+}
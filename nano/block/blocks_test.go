@@ -1,9 +1,14 @@
 package block
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
 	"testing"
 
 	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/crypto/ed25519"
 	"littleriver.cc/go-nano/nano/internal/util"
 )
 
@@ -13,26 +18,35 @@ var (
 		Representative: util.MustDecodeHex32("e89208dd038fbb269987689621d52292ae9c35941a7484756ecced92a65093ba"),
 		Address:        util.MustDecodeHex32("e89208dd038fbb269987689621d52292ae9c35941a7484756ecced92a65093ba"),
 		Work:           0x62f05417dd3fb691,
-		Signature:      util.MustDecodeHex64("9f0c933c8ade004d808ea1985fa746a7e95ba2a38f867640f53ec8f180bdfe9e2c1268dead7c2664f356e37aba362bc58e46dba03e523a7b5a19e4b6eb12bb02"),
+		Sig:            util.MustDecodeHex64("9f0c933c8ade004d808ea1985fa746a7e95ba2a38f867640f53ec8f180bdfe9e2c1268dead7c2664f356e37aba362bc58e46dba03e523a7b5a19e4b6eb12bb02"),
 	}
 	sendBlock = &SendBlock{
 		PreviousHash: util.MustDecodeHex32("4270f4fb3a820fe81827065f967a9589df5ca860443f812d21ece964ac359e05"),
 		Destination:  util.MustDecodeHex32("0000000000000000000000000000000000000000000000000000000000000000"),
 		Balance:      nano.ParseBalanceInts(0, 0),
 		Work:         0x7202df8a7c380578,
-		Signature:    util.MustDecodeHex64("047115cb577ac78f5c66ad79bbf47540de97a441456004190f22025fe4255285f57010d962601ae64c266c98fa22973dd95ac62309634940b727ac69f0c86d03"),
+		Sig:          util.MustDecodeHex64("047115cb577ac78f5c66ad79bbf47540de97a441456004190f22025fe4255285f57010d962601ae64c266c98fa22973dd95ac62309634940b727ac69f0c86d03"),
 	}
 	receiveBlock = &ReceiveBlock{
 		PreviousHash: util.MustDecodeHex32("80d6c93ee26f64353b5a69b3ce641f8de244fdcc79798502eb43d8fdefc93976"),
 		SourceHash:   util.MustDecodeHex32("1036e1153620383adcaf49ab7c5f27c07233d98df573a4f19ce75dbcc7f5ad9b"),
 		Work:         0xf0d8f54f62e0e757,
-		Signature:    util.MustDecodeHex64("bbe8ba0ab966e209ea1f661023b76f3df453f0714a96be2da5e717a78dcdf7c59d83bce96fe3285bbe2ef151c6cccbfee0c758dad25956a64f73e2d6c8366f0b"),
+		Sig:          util.MustDecodeHex64("bbe8ba0ab966e209ea1f661023b76f3df453f0714a96be2da5e717a78dcdf7c59d83bce96fe3285bbe2ef151c6cccbfee0c758dad25956a64f73e2d6c8366f0b"),
 	}
 	changeBlock = &ChangeBlock{
 		PreviousHash:   util.MustDecodeHex32("3abd41f575184a02b28f98f1c71684c8f6adc0f7334eb32e8232cdad65609e23"),
 		Representative: util.MustDecodeHex32("7d5c67cf17432c5c88fa739a3cc88f894da3eec7b977a804780977cae35fa5a8"),
 		Work:           0x8f48c0b00946163c,
-		Signature:      util.MustDecodeHex64("1009a0c2fbc189dc41d13daa9d7a6e1ab2d6c4e06200aeca1b7ae0c27bf454b2c60f446df0d69a877d3a98e3128ab3442058172fbd965024519630ace93b670e"),
+		Sig:            util.MustDecodeHex64("1009a0c2fbc189dc41d13daa9d7a6e1ab2d6c4e06200aeca1b7ae0c27bf454b2c60f446df0d69a877d3a98e3128ab3442058172fbd965024519630ace93b670e"),
+	}
+	stateBlock = &StateBlock{
+		Address:        util.MustDecodeHex32("ddd378054e7f57e0b9352ffe176d76322cf10ca7f4945415fe50e84c60583dfb"),
+		PreviousHash:   util.MustDecodeHex32("f47b23107e5f34b2ce06f562b5c435df72a533251cb414c51b2b62a8f63a00e4"),
+		Representative: util.MustDecodeHex32("3fe80b4bc842e82c1c18abfeec47ea989e63953bc82ac411f304d13833d52a56"),
+		Balance:        nano.ParseBalanceInts(0x36, 0x35c9adc5dea00000),
+		Link:           util.MustDecodeHex32("19d3d919475deed4696b5d13018151d1af88b2bd3bcff048b45031c1f36d1858"),
+		Work:           0xcab7404f0b5449d0,
+		Sig:            util.MustDecodeHex64("3bfba64a775550e6d49df1eb8eec2136dcd74f090e2ed658fbd9e80f17cb1c9f9f7bde2b93d95558ec2f277fff15fd11e6e2162a1714731b743d1e941fa4560a"),
 	}
 )
 
@@ -47,7 +61,7 @@ func TestBlockOpenMarshal(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if blk.Hash() != openBlock.Hash() || blk.Signature != openBlock.Signature || blk.Work != openBlock.Work {
+	if blk.Hash() != openBlock.Hash() || blk.Sig != openBlock.Sig || blk.Work != openBlock.Work {
 		t.Fatalf("blocks not equal")
 	}
 }
@@ -63,7 +77,7 @@ func TestBlockSendMarshal(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if blk.Hash() != sendBlock.Hash() || blk.Signature != sendBlock.Signature || blk.Work != sendBlock.Work {
+	if blk.Hash() != sendBlock.Hash() || blk.Sig != sendBlock.Sig || blk.Work != sendBlock.Work {
 		t.Fatalf("blocks not equal")
 	}
 }
@@ -79,7 +93,7 @@ func TestBlockReceiveMarshal(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if blk.Hash() != receiveBlock.Hash() || blk.Signature != receiveBlock.Signature || blk.Work != receiveBlock.Work {
+	if blk.Hash() != receiveBlock.Hash() || blk.Sig != receiveBlock.Sig || blk.Work != receiveBlock.Work {
 		t.Fatalf("blocks not equal")
 	}
 }
@@ -95,7 +109,222 @@ func TestBlockChangeMarshal(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if blk.Hash() != changeBlock.Hash() || blk.Signature != changeBlock.Signature || blk.Work != changeBlock.Work {
+	if blk.Hash() != changeBlock.Hash() || blk.Sig != changeBlock.Sig || blk.Work != changeBlock.Work {
+		t.Fatalf("blocks not equal")
+	}
+}
+
+func TestBlockStateMarshal(t *testing.T) {
+	bytes, err := stateBlock.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var blk StateBlock
+	if err = blk.UnmarshalBinary(bytes); err != nil {
+		t.Fatal(err)
+	}
+
+	if blk != *stateBlock {
+		t.Fatalf("blocks not equal")
+	}
+}
+
+func TestBlockStateHash(t *testing.T) {
+	// a send block from the live network
+	expected := "ff0144381cff0b2c079a115e7ada7e96f43fd219446e7524c48d1cc9900c4f17"
+	if hash := stateBlock.Hash(); hash.String() != expected {
+		t.Fatalf("expected: %s, got: %s", expected, hash)
+	}
+}
+
+func TestBlockStateSign(t *testing.T) {
+	if !stateBlock.VerifySignature() {
+		t.Fatalf("signature of live block rejected")
+	}
+
+	var zero [32]byte
+	pub, key, err := ed25519.GenerateKey(bytes.NewReader(zero[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blk := *stateBlock
+	if err = blk.Sign(key); err != ErrKeyMismatch {
+		t.Fatalf("expected key mismatch error, got: %v", err)
+	}
+	if blk.Sig != stateBlock.Sig {
+		t.Fatalf("signature changed after failed sign")
+	}
+
+	copy(blk.Address[:], pub)
+	if err = blk.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	if !blk.VerifySignature() {
+		t.Fatalf("valid signature rejected")
+	}
+
+	blk.Balance = nano.ParseBalanceInts(0, 1)
+	if blk.VerifySignature() {
+		t.Fatalf("signature of tampered block accepted")
+	}
+}
+
+func TestBlockStateJSON(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/state_block.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var blk StateBlock
+	if err = json.Unmarshal(data, &blk); err != nil {
+		t.Fatal(err)
+	}
+	if blk != *stateBlock {
 		t.Fatalf("blocks not equal")
 	}
+
+	out, err := blk.MarshalJSONLinkAsAccount()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, actual map[string]interface{}
+	if err = json.Unmarshal(data, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(out, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected: %v, got: %v", expected, actual)
+	}
+
+	if err = json.Unmarshal([]byte(`{"type":"send"}`), &blk); err != ErrBadBlockType {
+		t.Fatalf("expected bad block type error, got: %v", err)
+	}
+}
+
+func TestBlockStateJSONGolden(t *testing.T) {
+	tests := map[string]func() ([]byte, error){
+		"testdata/state_block.golden":                 stateBlock.MarshalJSON,
+		"testdata/state_block_link_as_account.golden": stateBlock.MarshalJSONLinkAsAccount,
+	}
+
+	for file, marshal := range tests {
+		expected, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(bytes.TrimSpace(expected), out) {
+			t.Errorf("(%s) expected: %s, got: %s", file, expected, out)
+		}
+	}
+}
+
+func TestBlockLegacyHash(t *testing.T) {
+	// blocks from the live network
+	tests := []struct {
+		blk  Block
+		hash string
+	}{
+		{openBlock, "991cf190094c00f0b68e2e5f75f6bee95a2e0bd93ceaa4a6734db9f19b728948"},
+		{sendBlock, "cf38c961abf03da497b4c8f290583af5c7b28d9df34ab3641598ff09e901430d"},
+		{receiveBlock, "cf65f8fd3dbc1ff066949f558f67c992040b3964bc3b1bdbf6a708e9db88d093"},
+		{changeBlock, "30a2de9a1e1269fc7fd1d7e044a7cf463210f5707b88822755f6e5139a3cadd2"},
+		{
+			&SendBlock{
+				PreviousHash: util.MustDecodeHex32("991cf190094c00f0b68e2e5f75f6bee95a2e0bd93ceaa4a6734db9f19b728948"),
+				Destination:  util.MustDecodeHex32("059f68aab29de0d3a27443625c7ea9cddb6517a8b76fe37727ef6a4d76832ad5"),
+				Balance:      nano.ParseBalanceInts(0xfd89d89d89d89d89, 0xd89d89d89d89d89d),
+			},
+			"a170d51b94e00371ace76e35ac81dc9405d5d04d4cebc399aeace07ae05dd293",
+		},
+	}
+
+	for _, test := range tests {
+		if hash := test.blk.Hash(); hash.String() != test.hash {
+			t.Errorf("bad %s block hash, expected: %s, got: %s", Name(test.blk.ID()), test.hash, hash)
+		}
+	}
+
+	hash := openBlock.Hash()
+	if sig := Block(openBlock).Signature(); !openBlock.Address.Verify(hash[:], sig[:]) {
+		t.Errorf("signature of genesis block rejected")
+	}
+}
+
+func TestBlockStateRoot(t *testing.T) {
+	if root := stateBlock.Root(); root != stateBlock.PreviousHash {
+		t.Fatalf("expected previous hash as root, got: %s", root)
+	}
+
+	blk := *stateBlock
+	blk.PreviousHash = Hash{}
+	if root := blk.Root(); root != Hash(blk.Address) {
+		t.Fatalf("expected account as root, got: %s", root)
+	}
+
+	if !stateBlock.Valid(0xffffffc000000000) {
+		t.Fatalf("work of live block rejected")
+	}
+}
+
+func TestBlockStateValidate(t *testing.T) {
+	if err := stateBlock.Validate(); err != nil {
+		t.Fatalf("live block rejected: %v", err)
+	}
+
+	// an open block receiving funds and a change block without a link are fine
+	open := *stateBlock
+	open.PreviousHash = Hash{}
+	change := *stateBlock
+	change.Link = Hash{}
+	full := *stateBlock
+	full.Balance = nano.MaxSupply
+	for _, blk := range []StateBlock{open, change, full} {
+		if err := blk.Validate(); err != nil {
+			t.Errorf("valid block rejected: %v", err)
+		}
+	}
+
+	// the bound is the real supply, not a round number of Nano
+	supply, err := nano.ParseBalance("133248297920938463463374607431768211455", "raw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	full.Balance = supply
+	if err = full.Validate(); err != nil {
+		t.Errorf("block with the full supply rejected: %v", err)
+	}
+	full.Balance = supply.Add(nano.ParseBalanceInts(0, 1))
+	if err = full.Validate(); err != ErrBalanceExceedsSupply {
+		t.Errorf("expected: %v, got: %v", ErrBalanceExceedsSupply, err)
+	}
+
+	tests := map[error]func(*StateBlock){
+		ErrZeroAccount: func(blk *StateBlock) {
+			blk.Address = nano.Address{}
+		},
+		ErrBalanceExceedsSupply: func(blk *StateBlock) {
+			blk.Balance = nano.MaxSupply.Add(nano.ParseBalanceInts(0, 1))
+		},
+		ErrOpenWithoutLink: func(blk *StateBlock) {
+			blk.PreviousHash = Hash{}
+			blk.Link = Hash{}
+		},
+	}
+	for expected, modify := range tests {
+		blk := *stateBlock
+		modify(&blk)
+		if err := blk.Validate(); err != expected {
+			t.Errorf("expected: %v, got: %v", expected, err)
+		}
+	}
 }
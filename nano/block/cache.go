@@ -0,0 +1,88 @@
+package block
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// WorkCache caches generated work by root. It is safe for concurrent use.
+type WorkCache struct {
+	size    int
+	lock    sync.Mutex
+	entries map[Hash]*list.Element
+	order   *list.List
+}
+
+type workCacheEntry struct {
+	root Hash
+	work Work
+}
+
+// NewWorkCache creates a new work cache holding at most size entries, evicting
+// the least recently used entry when it is full. A size of zero or less means
+// the cache is unbounded.
+func NewWorkCache(size int) *WorkCache {
+	return &WorkCache{
+		size:    size,
+		entries: make(map[Hash]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// Get returns the cached work for the given root, if any.
+func (c *WorkCache) Get(root Hash) (Work, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.entries[root]
+	if !ok {
+		return 0, false
+	}
+
+	c.order.MoveToFront(elem)
+	return elem.Value.(*workCacheEntry).work, true
+}
+
+// Put adds the work for the given root to the cache.
+func (c *WorkCache) Put(root Hash, work Work) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, ok := c.entries[root]; ok {
+		elem.Value.(*workCacheEntry).work = work
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[root] = c.order.PushFront(&workCacheEntry{root: root, work: work})
+	if c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*workCacheEntry).root)
+	}
+}
+
+// Len returns the amount of entries in the cache.
+func (c *WorkCache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.order.Len()
+}
+
+// Generate returns the cached work for the given root if it meets the
+// threshold. Otherwise, new work is generated with GenerateWork and added to
+// the cache.
+func (c *WorkCache) Generate(ctx context.Context, root Hash, threshold uint64) (Work, error) {
+	if work, ok := c.Get(root); ok && ValidateWork(root, work, threshold) {
+		return work, nil
+	}
+
+	work, err := GenerateWork(ctx, root, threshold)
+	if err != nil {
+		return 0, err
+	}
+
+	c.Put(root, work)
+	return work, nil
+}
@@ -0,0 +1,88 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestBlockWorkCache(t *testing.T) {
+	cache := NewWorkCache(2)
+
+	roots := []Hash{{1}, {2}, {3}}
+	if _, ok := cache.Get(roots[0]); ok {
+		t.Fatalf("unexpected hit in empty cache")
+	}
+
+	cache.Put(roots[0], 1)
+	cache.Put(roots[1], 2)
+	if work, ok := cache.Get(roots[0]); !ok || work != 1 {
+		t.Fatalf("expected hit with work 1, got: %v, %d", ok, work)
+	}
+
+	// the second root is now the least recently used
+	cache.Put(roots[2], 3)
+	if _, ok := cache.Get(roots[1]); ok {
+		t.Errorf("expected the least recently used root to be evicted")
+	}
+	if work, ok := cache.Get(roots[0]); !ok || work != 1 {
+		t.Errorf("expected hit with work 1, got: %v, %d", ok, work)
+	}
+	if work, ok := cache.Get(roots[2]); !ok || work != 3 {
+		t.Errorf("expected hit with work 3, got: %v, %d", ok, work)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got: %d", cache.Len())
+	}
+
+	cache.Put(roots[0], 4)
+	if work, _ := cache.Get(roots[0]); work != 4 {
+		t.Errorf("expected updated work 4, got: %d", work)
+	}
+}
+
+func TestBlockWorkCacheConcurrent(t *testing.T) {
+	cache := NewWorkCache(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				root := Hash{byte(i), byte(j)}
+				cache.Put(root, Work(j))
+				cache.Get(root)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if cache.Len() != 800 {
+		t.Errorf("expected 800 entries, got: %d", cache.Len())
+	}
+}
+
+func TestBlockWorkCacheGenerate(t *testing.T) {
+	cache := NewWorkCache(1)
+	hash := mustDecodeHash(t, "6529c605d4016f486b60861c49ddad128d77642e748b3fe13be411f00ba0918b")
+
+	cache.Put(hash, 0xc2c306caf73b836f)
+	work, err := cache.Generate(context.Background(), hash, ThresholdBaseV1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if work != 0xc2c306caf73b836f {
+		t.Fatalf("expected cached work, got: %s", work)
+	}
+
+	// cached work that doesn't meet the threshold is replaced
+	cache.Put(hash, 0)
+	threshold := uint64(0xff00000000000000)
+	if work, err = cache.Generate(context.Background(), hash, threshold); err != nil {
+		t.Fatal(err)
+	}
+	if cached, _ := cache.Get(hash); cached != work || !ValidateWork(hash, work, threshold) {
+		t.Fatalf("expected valid generated work in cache, got: %s", cached)
+	}
+}
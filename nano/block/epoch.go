@@ -0,0 +1,30 @@
+package block
+
+var epochLinks = map[int]Hash{
+	1: epochLink("epoch v1 block"),
+	2: epochLink("epoch v2 block"),
+}
+
+func epochLink(marker string) Hash {
+	var link Hash
+	copy(link[:], marker)
+	return link
+}
+
+// EpochLink returns the link value that marks an epoch block upgrading accounts
+// to the given epoch version. It returns the zero hash for unknown versions.
+func EpochLink(epoch int) Hash {
+	return epochLinks[epoch]
+}
+
+// IsEpoch reports whether this block is an epoch block, based on its link. A
+// receive block always links to the hash of a send block, which cannot be equal
+// to an epoch marker.
+func (b *StateBlock) IsEpoch() bool {
+	for _, link := range epochLinks {
+		if b.Link == link {
+			return true
+		}
+	}
+	return false
+}
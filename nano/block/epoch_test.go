@@ -0,0 +1,36 @@
+package block
+
+import "testing"
+
+func TestBlockStateEpoch(t *testing.T) {
+	links := map[int]string{
+		1: "65706f636820763120626c6f636b000000000000000000000000000000000000",
+		2: "65706f636820763220626c6f636b000000000000000000000000000000000000",
+	}
+
+	for epoch, link := range links {
+		hash := EpochLink(epoch)
+		if hash.String() != link {
+			t.Fatalf("bad link for epoch %d: %s", epoch, hash)
+		}
+
+		blk := StateBlock{Link: hash}
+		if !blk.IsEpoch() {
+			t.Errorf("epoch %d block not detected", epoch)
+		}
+	}
+
+	if !EpochLink(3).IsZero() {
+		t.Errorf("expected zero link for unknown epoch")
+	}
+
+	// a receive of a zero amount is not an epoch block
+	blk := *stateBlock
+	if blk.IsEpoch() {
+		t.Errorf("live send block detected as epoch block")
+	}
+	blk.Link = stateBlock.PreviousHash
+	if blk.IsEpoch() {
+		t.Errorf("receive block detected as epoch block")
+	}
+}
@@ -2,17 +2,47 @@ package block
 
 import (
 	"encoding/hex"
-	"fmt"
+	"errors"
 
 	"golang.org/x/crypto/blake2b"
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/crypto"
 )
 
 const (
 	HashSize = blake2b.Size256
 )
 
+var (
+	ErrBadHashSize = errors.New("bad block hash size")
+)
+
 type Hash [HashSize]byte
 
+// Hash256 returns the blake2b-256 digest of the concatenation of the given
+// chunks, which is how block hashes are computed.
+func Hash256(data ...[]byte) Hash {
+	var hash Hash
+	copy(hash[:], crypto.Hash(HashSize, data...))
+	return hash
+}
+
+// ParseHash parses the given hex representation of a block hash.
+func ParseHash(s string) (Hash, error) {
+	var hash Hash
+	if len(s) != hex.EncodedLen(HashSize) {
+		return hash, ErrBadHashSize
+	}
+
+	if _, err := hex.Decode(hash[:], []byte(s)); err != nil {
+		return Hash{}, err
+	}
+
+	return hash, nil
+}
+
+// IsZero reports whether this is the zero hash, which is used as the previous
+// hash of the first block of an account.
 func (h Hash) IsZero() bool {
 	for _, b := range h {
 		if b != 0 {
@@ -22,6 +52,38 @@ func (h Hash) IsZero() bool {
 	return true
 }
 
+// HashFromAddress returns the public key of the given address as a hash, as
+// used for the link of a send block.
+func HashFromAddress(address nano.Address) Hash {
+	return Hash(address)
+}
+
+// AsAddress interprets the hash as a public key and returns its address. This
+// is the link_as_account form of the link of a state block.
+func (h Hash) AsAddress() nano.Address {
+	return nano.Address(h)
+}
+
+// Hex returns the hex representation of the hash.
+func (h Hash) Hex() string {
+	return hex.EncodeToString(h[:])
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (h Hash) MarshalBinary() ([]byte, error) {
+	return h[:], nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (h *Hash) UnmarshalBinary(data []byte) error {
+	if len(data) != HashSize {
+		return ErrBadHashSize
+	}
+
+	copy(h[:], data)
+	return nil
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 func (h Hash) MarshalText() ([]byte, error) {
 	return []byte(h.String()), nil
@@ -29,13 +91,8 @@ func (h Hash) MarshalText() ([]byte, error) {
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
 func (h *Hash) UnmarshalText(text []byte) error {
-	size := hex.DecodedLen(len(text))
-	if size != HashSize {
-		return fmt.Errorf("bad block hash size: %d", size)
-	}
-
-	var hash [HashSize]byte
-	if _, err := hex.Decode(hash[:], text); err != nil {
+	hash, err := ParseHash(string(text))
+	if err != nil {
 		return err
 	}
 
@@ -45,5 +102,5 @@ func (h *Hash) UnmarshalText(text []byte) error {
 
 // String implements the fmt.Stringer interface.
 func (h Hash) String() string {
-	return hex.EncodeToString(h[:])
+	return h.Hex()
 }
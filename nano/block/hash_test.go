@@ -0,0 +1,98 @@
+package block
+
+import (
+	"strings"
+	"testing"
+
+	"littleriver.cc/go-nano/nano"
+)
+
+func TestBlockHash(t *testing.T) {
+	s := "991cf190094c00f0b68e2e5f75f6bee95a2e0bd93ceaa4a6734db9f19b728948"
+	hash, err := ParseHash(strings.ToUpper(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash.Hex() != s || hash.IsZero() {
+		t.Fatalf("expected: %s, got: %s", s, hash)
+	}
+
+	data, err := hash.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Hash
+	if err = decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != hash {
+		t.Fatalf("expected: %s, got: %s", hash, decoded)
+	}
+	if err = decoded.UnmarshalBinary(data[1:]); err != ErrBadHashSize {
+		t.Errorf("expected bad hash size error, got: %v", err)
+	}
+
+	text, err := hash.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded = Hash{}
+	if err = decoded.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != hash {
+		t.Fatalf("expected: %s, got: %s", hash, decoded)
+	}
+
+	for _, s := range []string{"", s[1:], s + "0", s + "00"} {
+		if _, err = ParseHash(s); err != ErrBadHashSize {
+			t.Errorf("(%s) expected bad hash size error, got: %v", s, err)
+		}
+	}
+	if _, err = ParseHash(s[1:] + "z"); err == nil {
+		t.Errorf("expected an error for an invalid hex character")
+	}
+
+	zero, err := ParseHash(strings.Repeat("0", 64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !zero.IsZero() || zero != (Hash{}) {
+		t.Errorf("expected the zero hash")
+	}
+}
+
+func TestBlockHashAsAddress(t *testing.T) {
+	// the link and link_as_account of a send block from the live network
+	link, err := ParseHash("19d3d919475deed4696b5d13018151d1af88b2bd3bcff048b45031c1f36d1858")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, err := nano.ParseAddress("nano_18gmu6engqhgtjnppqam181o5nfhj4sdtgyhy36dan3jr9spt84rzwmktafc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res := link.AsAddress(); res != address {
+		t.Fatalf("expected: %s, got: %s", address, res)
+	}
+	if res := HashFromAddress(address); res != link {
+		t.Fatalf("expected: %s, got: %s", link, res)
+	}
+	if stateBlock.Link.AsAddress() != address {
+		t.Fatalf("link of the state block does not match its link_as_account")
+	}
+}
+
+func TestBlockHash256(t *testing.T) {
+	expected := "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"
+	if res := Hash256([]byte("abc")); res.Hex() != expected {
+		t.Errorf("expected: %s, got: %s", expected, res)
+	}
+	if res := Hash256([]byte("a"), []byte("b"), []byte("c")); res.Hex() != expected {
+		t.Errorf("expected: %s, got: %s", expected, res)
+	}
+	if res := Hash256(); res.Hex() != "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8" {
+		t.Errorf("unexpected digest of no data: %s", res)
+	}
+}
@@ -0,0 +1,84 @@
+package block
+
+import (
+	"encoding/json"
+	"strings"
+
+	"littleriver.cc/go-nano/nano"
+)
+
+// stateBlockJSON mirrors the JSON representation of a state block used by the
+// RPC interface of the reference node. The order of the fields matters.
+type stateBlockJSON struct {
+	Type           string        `json:"type"`
+	Account        nano.Address  `json:"account"`
+	Previous       string        `json:"previous"`
+	Representative nano.Address  `json:"representative"`
+	Balance        nano.Balance  `json:"balance"`
+	Link           string        `json:"link"`
+	LinkAsAccount  *nano.Address `json:"link_as_account,omitempty"`
+	Signature      string        `json:"signature"`
+	Work           Work          `json:"work"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The output matches the
+// format of the reference node, which prints the fields in a fixed order and
+// hashes and signatures as uppercase hex.
+func (b *StateBlock) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.jsonValue(false))
+}
+
+// MarshalJSONLinkAsAccount is like MarshalJSON, but also includes the
+// link_as_account field, which holds the link interpreted as an address.
+func (b *StateBlock) MarshalJSONLinkAsAccount() ([]byte, error) {
+	return json.Marshal(b.jsonValue(true))
+}
+
+func (b *StateBlock) jsonValue(linkAsAccount bool) stateBlockJSON {
+	v := stateBlockJSON{
+		Type:           Name(idBlockState),
+		Account:        b.Address,
+		Previous:       strings.ToUpper(b.PreviousHash.String()),
+		Representative: b.Representative,
+		Balance:        b.Balance,
+		Link:           strings.ToUpper(b.Link.String()),
+		Signature:      strings.ToUpper(b.Sig.String()),
+		Work:           b.Work,
+	}
+	if linkAsAccount {
+		address := b.Link.AsAddress()
+		v.LinkAsAccount = &address
+	}
+	return v
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (b *StateBlock) UnmarshalJSON(data []byte) error {
+	var v stateBlockJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	if v.Type != Name(idBlockState) {
+		return ErrBadBlockType
+	}
+
+	blk := StateBlock{
+		Address:        v.Account,
+		Representative: v.Representative,
+		Balance:        v.Balance,
+		Work:           v.Work,
+	}
+	if err := blk.PreviousHash.UnmarshalText([]byte(v.Previous)); err != nil {
+		return err
+	}
+	if err := blk.Link.UnmarshalText([]byte(v.Link)); err != nil {
+		return err
+	}
+	if err := blk.Sig.UnmarshalText([]byte(v.Signature)); err != nil {
+		return err
+	}
+
+	*b = blk
+	return nil
+}
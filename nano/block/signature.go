@@ -1,6 +1,7 @@
 package block
 
 import (
+	"crypto/subtle"
 	"encoding/hex"
 	"fmt"
 
@@ -13,6 +14,12 @@ const (
 
 type Signature [SignatureSize]byte
 
+// Equal reports whether s and s2 are the same signature. The comparison is done
+// in constant time.
+func (s Signature) Equal(s2 Signature) bool {
+	return subtle.ConstantTimeCompare(s[:], s2[:]) == 1
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 func (s Signature) MarshalText() ([]byte, error) {
 	return []byte(s.String()), nil
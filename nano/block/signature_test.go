@@ -0,0 +1,20 @@
+package block
+
+import (
+	"testing"
+)
+
+func TestBlockSignatureEqual(t *testing.T) {
+	sig := stateBlock.Sig
+	if !sig.Equal(stateBlock.Sig) {
+		t.Fatalf("signature is not equal to itself")
+	}
+
+	for _, i := range []int{0, SignatureSize / 2, SignatureSize - 1} {
+		other := sig
+		other[i] ^= 1
+		if sig.Equal(other) {
+			t.Errorf("signatures differing in byte %d are equal", i)
+		}
+	}
+}
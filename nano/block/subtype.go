@@ -0,0 +1,78 @@
+package block
+
+import (
+	"errors"
+	"fmt"
+
+	"littleriver.cc/go-nano/nano"
+)
+
+// Subtype describes what a state block does to its account chain.
+type Subtype byte
+
+const (
+	SubtypeSend Subtype = iota
+	SubtypeReceive
+	SubtypeChange
+	SubtypeEpoch
+	// SubtypeOpen is a receive block that opens an account.
+	SubtypeOpen
+)
+
+var (
+	ErrUnknownSubtype = errors.New("unknown block subtype")
+
+	subtypeNames = map[Subtype]string{
+		SubtypeSend:    "send",
+		SubtypeReceive: "receive",
+		SubtypeChange:  "change",
+		SubtypeEpoch:   "epoch",
+		SubtypeOpen:    "open",
+	}
+)
+
+// ParseSubtype parses the name of a subtype, as used by the node. It returns an
+// error wrapping ErrUnknownSubtype if the name is not known.
+func ParseSubtype(s string) (Subtype, error) {
+	for subtype, name := range subtypeNames {
+		if name == s {
+			return subtype, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownSubtype, s)
+}
+
+// String implements the fmt.Stringer interface. It returns the name of the
+// subtype used by the node.
+func (s Subtype) String() string {
+	if name, ok := subtypeNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Subtype(%d)", s)
+}
+
+// Amount returns the amount of funds moved by this block along with its
+// subtype, given the balance of the account before the block. A block that does
+// not change the balance is reported as either an epoch or a change block, with
+// an amount of zero.
+func (b *StateBlock) Amount(previousBalance nano.Balance) (nano.Balance, Subtype, error) {
+	switch b.Balance.Cmp(previousBalance) {
+	case -1:
+		amount, err := previousBalance.CheckedSub(b.Balance)
+		if err != nil {
+			return nano.ZeroBalance, SubtypeSend, err
+		}
+		return amount, SubtypeSend, nil
+	case 1:
+		amount, err := b.Balance.CheckedSub(previousBalance)
+		if err != nil {
+			return nano.ZeroBalance, SubtypeReceive, err
+		}
+		return amount, SubtypeReceive, nil
+	default:
+		if b.IsEpoch() {
+			return nano.ZeroBalance, SubtypeEpoch, nil
+		}
+		return nano.ZeroBalance, SubtypeChange, nil
+	}
+}
@@ -0,0 +1,75 @@
+package block
+
+import (
+	"errors"
+	"testing"
+
+	"littleriver.cc/go-nano/nano"
+)
+
+func TestBlockStateAmount(t *testing.T) {
+	tests := []struct {
+		previous nano.Balance
+		balance  nano.Balance
+		link     Hash
+		amount   nano.Balance
+		subtype  Subtype
+	}{
+		{nano.ParseBalanceInts(0, 100), nano.ParseBalanceInts(0, 40), Hash{}, nano.ParseBalanceInts(0, 60), SubtypeSend},
+		{nano.ParseBalanceInts(1, 0), nano.ZeroBalance, Hash{}, nano.ParseBalanceInts(1, 0), SubtypeSend},
+		{nano.ParseBalanceInts(0, 40), nano.ParseBalanceInts(0, 100), Hash{}, nano.ParseBalanceInts(0, 60), SubtypeReceive},
+		{nano.ZeroBalance, nano.GenesisBalance, Hash{}, nano.GenesisBalance, SubtypeReceive},
+		{nano.ParseBalanceInts(0, 100), nano.ParseBalanceInts(0, 100), Hash{}, nano.ZeroBalance, SubtypeChange},
+		{nano.ZeroBalance, nano.ZeroBalance, Hash{}, nano.ZeroBalance, SubtypeChange},
+		{nano.ParseBalanceInts(0, 100), nano.ParseBalanceInts(0, 100), EpochLink(1), nano.ZeroBalance, SubtypeEpoch},
+		{nano.ZeroBalance, nano.ZeroBalance, EpochLink(2), nano.ZeroBalance, SubtypeEpoch},
+	}
+
+	for _, test := range tests {
+		blk := StateBlock{Balance: test.balance, Link: test.link}
+		amount, subtype, err := blk.Amount(test.previous)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if subtype != test.subtype {
+			t.Errorf("expected subtype %d, got: %d", test.subtype, subtype)
+		}
+		if !amount.Equal(test.amount) {
+			t.Errorf("expected amount %s, got: %s", test.amount.UnitString("raw", 0), amount.UnitString("raw", 0))
+		}
+	}
+}
+
+func TestBlockSubtypeString(t *testing.T) {
+	tests := map[Subtype]string{
+		SubtypeSend:    "send",
+		SubtypeReceive: "receive",
+		SubtypeChange:  "change",
+		SubtypeEpoch:   "epoch",
+		SubtypeOpen:    "open",
+	}
+
+	for subtype, name := range tests {
+		if s := subtype.String(); s != name {
+			t.Errorf("expected: %s, got: %s", name, s)
+		}
+
+		res, err := ParseSubtype(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res != subtype {
+			t.Errorf("(%s) expected: %d, got: %d", name, subtype, res)
+		}
+	}
+
+	for _, s := range []string{"", "Send", "state", "unknown"} {
+		if _, err := ParseSubtype(s); !errors.Is(err, ErrUnknownSubtype) {
+			t.Errorf("(%q) expected unknown subtype error, got: %v", s, err)
+		}
+	}
+	if s := Subtype(100).String(); s != "Subtype(100)" {
+		t.Errorf("unexpected name of unknown subtype: %s", s)
+	}
+}
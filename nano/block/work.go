@@ -1,16 +1,36 @@
 package block
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"hash"
+	"math"
+	"runtime"
 
 	"golang.org/x/crypto/blake2b"
+	"littleriver.cc/go-nano/nano/crypto/random"
 )
 
 const (
 	WorkSize = 8
+
+	// workCheckInterval is the amount of attempts a worker makes between checks
+	// for cancellation.
+	workCheckInterval = 1 << 12
+)
+
+const (
+	// ThresholdBaseV1 is the work threshold for all blocks before the epoch v2
+	// upgrade.
+	ThresholdBaseV1 uint64 = 0xffffffc000000000
+	// ThresholdSendV2 is the work threshold for send and change blocks after the
+	// epoch v2 upgrade.
+	ThresholdSendV2 uint64 = 0xfffffff800000000
+	// ThresholdReceiveV2 is the work threshold for receive and epoch blocks after
+	// the epoch v2 upgrade.
+	ThresholdReceiveV2 uint64 = 0xfffffe0000000000
 )
 
 type Work uint64
@@ -26,6 +46,53 @@ func (w Work) Valid(root Hash, threshold uint64) bool {
 	return NewWorker(w, root, threshold).Valid()
 }
 
+// ThresholdForSubtype returns the epoch v2 work threshold for blocks of the
+// given subtype.
+func ThresholdForSubtype(subtype Subtype) uint64 {
+	switch subtype {
+	case SubtypeReceive, SubtypeOpen, SubtypeEpoch:
+		return ThresholdReceiveV2
+	default:
+		return ThresholdSendV2
+	}
+}
+
+// WorkValue returns the blake2b based value of the given work for the root. The
+// work is valid if this value meets the threshold.
+func WorkValue(root Hash, work Work) uint64 {
+	return NewWorker(work, root, 0).value()
+}
+
+// DifficultyMultiplier returns how many times more difficult it is to reach the
+// given difficulty compared to the base threshold, using the same formula as the
+// reference node.
+func DifficultyMultiplier(difficulty, baseThreshold uint64) float64 {
+	return float64(-baseThreshold) / float64(-difficulty)
+}
+
+// MultiplierToDifficulty is the inverse of DifficultyMultiplier. It returns the
+// difficulty that is the given amount of times more difficult to reach than the
+// base threshold. The multiplier must be greater than zero.
+func MultiplierToDifficulty(multiplier float64, baseThreshold uint64) uint64 {
+	reverse := float64(-baseThreshold) / multiplier
+	if reverse >= math.Exp2(64) {
+		return 0
+	}
+
+	// converting the reverse difficulty back truncates it, which can yield zero
+	// for huge multipliers, in that case the difficulty is as high as it gets
+	if r := uint64(reverse); r != 0 || baseThreshold == 0 || multiplier < 1 {
+		return -r
+	}
+	return math.MaxUint64
+}
+
+// ValidateWork reports whether the given work for the root meets the
+// threshold.
+func ValidateWork(root Hash, work Work, threshold uint64) bool {
+	return WorkValue(root, work) >= threshold
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 func (w Work) MarshalText() ([]byte, error) {
 	return []byte(w.String()), nil
@@ -70,6 +137,10 @@ func NewWorker(work Work, root Hash, threshold uint64) *Worker {
 }
 
 func (w *Worker) Valid() bool {
+	return w.value() >= w.Threshold
+}
+
+func (w *Worker) value() uint64 {
 	var workBytes [WorkSize]byte
 	binary.LittleEndian.PutUint64(workBytes[:], uint64(w.work))
 
@@ -77,9 +148,7 @@ func (w *Worker) Valid() bool {
 	w.hash.Write(workBytes[:])
 	w.hash.Write(w.root[:])
 
-	sum := w.hash.Sum(nil)
-	value := binary.LittleEndian.Uint64(sum)
-	return value >= w.Threshold
+	return binary.LittleEndian.Uint64(w.hash.Sum(nil))
 }
 
 func (w *Worker) Generate() Work {
@@ -95,3 +164,66 @@ func (w *Worker) Reset() {
 	w.work = 0
 	w.hash.Reset()
 }
+
+// GenerateWork searches for work for the given root that meets the threshold.
+// The search is spread over runtime.NumCPU() goroutines, each starting at a
+// random nonce, and stops as soon as one of them succeeds or the context is
+// done.
+func GenerateWork(ctx context.Context, root Hash, threshold uint64) (Work, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := runtime.NumCPU()
+	results := make(chan Work, workers)
+	for i := 0; i < workers; i++ {
+		var start [WorkSize]byte
+		if err := random.Bytes(start[:]); err != nil {
+			return 0, err
+		}
+
+		worker := NewWorker(Work(binary.LittleEndian.Uint64(start[:])), root, threshold)
+		go worker.generate(ctx, results)
+	}
+
+	select {
+	case work := <-results:
+		return work, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// GenerateWorkFrom is like GenerateWork, but searches on a single goroutine
+// starting at the given nonce and incrementing it. It always returns the first
+// valid work at or after start, which makes it suitable for tests that need
+// reproducible work. Use GenerateWork for anything else.
+func GenerateWorkFrom(ctx context.Context, root Hash, threshold uint64, start Work) (Work, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan Work, 1)
+	go NewWorker(start, root, threshold).generate(ctx, results)
+
+	select {
+	case work := <-results:
+		return work, nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+func (w *Worker) generate(ctx context.Context, results chan<- Work) {
+	for {
+		for i := 0; i < workCheckInterval; i++ {
+			if w.Valid() {
+				results <- w.work
+				return
+			}
+			w.work++
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package block
 
 import (
+	"context"
 	"encoding/hex"
+	"math"
 	"testing"
+	"time"
 )
 
 func TestBlockWork(t *testing.T) {
@@ -23,6 +26,152 @@ func TestBlockWork(t *testing.T) {
 	worker.Generate()
 }
 
+func TestBlockValidateWork(t *testing.T) {
+	threshold := ThresholdBaseV1
+	tests := []struct {
+		root  string
+		work  Work
+		valid bool
+	}{
+		{"6529c605d4016f486b60861c49ddad128d77642e748b3fe13be411f00ba0918b", 0xc2c306caf73b836f, true},
+		{"f47b23107e5f34b2ce06f562b5c435df72a533251cb414c51b2b62a8f63a00e4", 0xcab7404f0b5449d0, true},
+		{"6529c605d4016f486b60861c49ddad128d77642e748b3fe13be411f00ba0918b", 0xc2c306caf73b8370, false},
+		{"f47b23107e5f34b2ce06f562b5c435df72a533251cb414c51b2b62a8f63a00e4", 0xc2c306caf73b836f, false},
+	}
+
+	for _, test := range tests {
+		if valid := ValidateWork(mustDecodeHash(t, test.root), test.work, threshold); valid != test.valid {
+			t.Errorf("work %s for root %s: expected %t, got %t", test.work, test.root, test.valid, valid)
+		}
+	}
+}
+
+func TestBlockWorkValue(t *testing.T) {
+	tests := []struct {
+		root  string
+		work  Work
+		value uint64
+	}{
+		{"6529c605d4016f486b60861c49ddad128d77642e748b3fe13be411f00ba0918b", 0xc2c306caf73b836f, 0xffffffddd8fc6753},
+		{"f47b23107e5f34b2ce06f562b5c435df72a533251cb414c51b2b62a8f63a00e4", 0xcab7404f0b5449d0, 0xffffffd2b7ffab7b},
+		{"6529c605d4016f486b60861c49ddad128d77642e748b3fe13be411f00ba0918b", 0x000000000100b22a, 0xfffffe9691abcca7},
+	}
+
+	for _, test := range tests {
+		if value := WorkValue(mustDecodeHash(t, test.root), test.work); value != test.value {
+			t.Errorf("work %s for root %s: expected %x, got %x", test.work, test.root, test.value, value)
+		}
+	}
+}
+
+func TestBlockWorkThresholds(t *testing.T) {
+	hash := mustDecodeHash(t, "6529c605d4016f486b60861c49ddad128d77642e748b3fe13be411f00ba0918b")
+	work := Work(0x000000000100b22a)
+
+	receive := ThresholdForSubtype(SubtypeReceive)
+	if receive != ThresholdReceiveV2 || ThresholdForSubtype(SubtypeEpoch) != ThresholdReceiveV2 || ThresholdForSubtype(SubtypeOpen) != ThresholdReceiveV2 {
+		t.Fatalf("bad receive threshold: %x", receive)
+	}
+	send := ThresholdForSubtype(SubtypeSend)
+	if send != ThresholdSendV2 || ThresholdForSubtype(SubtypeChange) != ThresholdSendV2 {
+		t.Fatalf("bad send threshold: %x", send)
+	}
+
+	if !ValidateWork(hash, work, receive) {
+		t.Errorf("work rejected for receive threshold")
+	}
+	if ValidateWork(hash, work, send) {
+		t.Errorf("receive work accepted for send threshold")
+	}
+	if ValidateWork(hash, work, ThresholdBaseV1) {
+		t.Errorf("receive work accepted for v1 threshold")
+	}
+}
+
+func TestBlockDifficultyMultiplier(t *testing.T) {
+	tests := []struct {
+		difficulty uint64
+		multiplier float64
+	}{
+		{ThresholdBaseV1, 1},
+		{0xffffffe000000000, 2},
+		{ThresholdSendV2, 8},
+		{ThresholdReceiveV2, 0.125},
+	}
+
+	for _, test := range tests {
+		if multiplier := DifficultyMultiplier(test.difficulty, ThresholdBaseV1); multiplier != test.multiplier {
+			t.Errorf("difficulty %x: expected multiplier %g, got %g", test.difficulty, test.multiplier, multiplier)
+		}
+		if difficulty := MultiplierToDifficulty(test.multiplier, ThresholdBaseV1); difficulty != test.difficulty {
+			t.Errorf("multiplier %g: expected difficulty %x, got %x", test.multiplier, test.difficulty, difficulty)
+		}
+	}
+
+	difficulty := MultiplierToDifficulty(2.5, ThresholdBaseV1)
+	if multiplier := DifficultyMultiplier(difficulty, ThresholdBaseV1); math.Abs(multiplier-2.5) > 1e-9 {
+		t.Errorf("expected multiplier 2.5, got %g", multiplier)
+	}
+
+	if difficulty := MultiplierToDifficulty(math.MaxFloat64, ThresholdBaseV1); difficulty != math.MaxUint64 {
+		t.Errorf("expected max difficulty, got %x", difficulty)
+	}
+	if difficulty := MultiplierToDifficulty(1e-9, ThresholdBaseV1); difficulty != 0 {
+		t.Errorf("expected zero difficulty, got %x", difficulty)
+	}
+}
+
+func TestBlockGenerateWork(t *testing.T) {
+	threshold := uint64(0xff00000000000000)
+	hash := mustDecodeHash(t, "6529c605d4016f486b60861c49ddad128d77642e748b3fe13be411f00ba0918b")
+
+	work, err := GenerateWork(context.Background(), hash, threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !work.Valid(hash, threshold) {
+		t.Errorf("generated work not valid")
+	}
+	if len(work.String()) != WorkSize*2 {
+		t.Errorf("bad work string: %s", work)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err = GenerateWork(ctx, hash, ^uint64(0)); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded error, got: %v", err)
+	}
+}
+
+func TestBlockGenerateWorkFrom(t *testing.T) {
+	threshold := uint64(0xff00000000000000)
+	hash := mustDecodeHash(t, "6529c605d4016f486b60861c49ddad128d77642e748b3fe13be411f00ba0918b")
+
+	tests := map[Work]Work{
+		0:      0x25,
+		0x25:   0x25,
+		0x1000: 0x1163,
+	}
+	for start, expected := range tests {
+		work, err := GenerateWorkFrom(context.Background(), hash, threshold, start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if work != expected {
+			t.Errorf("(%s) expected: %s, got: %s", start, expected, work)
+		}
+	}
+	if value := WorkValue(hash, 0x25); value != 0xff5de5c9906dae4f {
+		t.Errorf("unexpected work value: %x", value)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := GenerateWorkFrom(ctx, hash, ^uint64(0), 0); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded error, got: %v", err)
+	}
+}
+
 func mustDecodeHash(t *testing.T, s string) Hash {
 	var hash Hash
 	bytes, err := hex.DecodeString(s)
@@ -1,2 +1,3 @@
-// Package crypto provides some helpful crypto functions (used internally).
+// Package crypto provides the blake2b hashing used throughout Nano along with
+// some other helpful crypto functions.
 package crypto
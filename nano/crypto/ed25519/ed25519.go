@@ -13,9 +13,9 @@ package ed25519
 // from SUPERCOP.
 
 import (
-	"bytes"
 	"crypto"
 	cryptorand "crypto/rand"
+	"crypto/subtle"
 	"errors"
 	"io"
 	"strconv"
@@ -46,6 +46,31 @@ func (priv PrivateKey) Public() crypto.PublicKey {
 	return PublicKey(publicKey)
 }
 
+// PublicKey returns the PublicKey corresponding to priv. Unlike Public, it
+// returns the concrete PublicKey type.
+func (priv PrivateKey) PublicKey() PublicKey {
+	return priv.Public().(PublicKey)
+}
+
+// Equal reports whether priv and x have the same value. The comparison is done
+// in constant time.
+func (priv PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(PrivateKey)
+	if !ok || len(priv) != len(xx) {
+		return false
+	}
+	return subtle.ConstantTimeCompare(priv, xx) == 1
+}
+
+// Zero overwrites priv with zeros. Callers should defer it once the key is no
+// longer needed, so the secret does not linger in memory. Signatures made with
+// a zeroed key are no longer valid for the original public key.
+func (priv PrivateKey) Zero() {
+	for i := range priv {
+		priv[i] = 0
+	}
+}
+
 // Sign signs the given message with priv.
 // Ed25519 performs two passes over messages to be signed and therefore cannot
 // handle pre-hashed messages. Thus opts.HashFunc() must return zero to
@@ -190,5 +215,21 @@ func Verify(publicKey PublicKey, message, sig []byte) bool {
 
 	var checkR [32]byte
 	R.ToBytes(&checkR)
-	return bytes.Equal(sig[:32], checkR[:])
+	return subtle.ConstantTimeCompare(sig[:32], checkR[:]) == 1
+}
+
+// Equal reports whether pub and x have the same value. The comparison is done
+// in constant time.
+func (pub PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(PublicKey)
+	if !ok || len(pub) != len(xx) {
+		return false
+	}
+	return subtle.ConstantTimeCompare(pub, xx) == 1
+}
+
+// Verify reports whether sig is a valid signature of message by pub. It will
+// panic if len(pub) is not PublicKeySize.
+func (pub PublicKey) Verify(message, sig []byte) bool {
+	return Verify(pub, message, sig)
 }
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/rand"
+	"encoding/hex"
 	"testing"
 
 	"littleriver.cc/go-nano/nano/crypto/ed25519/internal/edwards25519"
@@ -56,6 +57,93 @@ func TestSignVerify(t *testing.T) {
 	}
 }
 
+func TestSignVerifyMethods(t *testing.T) {
+	var zero zeroReader
+	public, private, _ := GenerateKey(zero)
+
+	if !bytes.Equal(private.PublicKey(), public) {
+		t.Fatalf("public keys do not match: %x vs %x", private.PublicKey(), public)
+	}
+
+	message := []byte("test message")
+	sig := Sign(private, message)
+	if !private.PublicKey().Verify(message, sig) {
+		t.Errorf("valid signature rejected")
+	}
+
+	sig[0] ^= 1
+	if public.Verify(message, sig) {
+		t.Errorf("tampered signature accepted")
+	}
+}
+
+func TestEqual(t *testing.T) {
+	var zero zeroReader
+	public, private, _ := GenerateKey(zero)
+	otherPublic, otherPrivate, _ := GenerateKey(rand.Reader)
+
+	if !public.Equal(public) || !public.Equal(PublicKey(append([]byte{}, public...))) {
+		t.Errorf("public key is not equal to itself")
+	}
+	if !private.Equal(private) || !private.Equal(PrivateKey(append([]byte{}, private...))) {
+		t.Errorf("private key is not equal to itself")
+	}
+	if public.Equal(otherPublic) || private.Equal(otherPrivate) {
+		t.Errorf("different keys are equal")
+	}
+
+	// the length and the type are checked first
+	if public.Equal(public[:PublicKeySize-1]) || private.Equal(private[:PrivateKeySize-1]) {
+		t.Errorf("truncated keys are equal")
+	}
+	if public.Equal([]byte(public)) || private.Equal(public) {
+		t.Errorf("values of a different type are equal")
+	}
+}
+
+func TestZero(t *testing.T) {
+	public, private, _ := GenerateKey(rand.Reader)
+	private.Zero()
+
+	for i, b := range private {
+		if b != 0 {
+			t.Fatalf("byte %d was not cleared", i)
+		}
+	}
+	if len(private) != PrivateKeySize {
+		t.Fatalf("unexpected private key size: %d", len(private))
+	}
+
+	message := []byte("test message")
+	if public.Verify(message, Sign(private, message)) {
+		t.Errorf("signature of a zeroed key accepted")
+	}
+}
+
+func TestVerifyGenesis(t *testing.T) {
+	// the signature of the genesis block of the live network
+	public := mustDecodeHex(t, "e89208dd038fbb269987689621d52292ae9c35941a7484756ecced92a65093ba")
+	hash := mustDecodeHex(t, "991cf190094c00f0b68e2e5f75f6bee95a2e0bd93ceaa4a6734db9f19b728948")
+	sig := mustDecodeHex(t, "9f0c933c8ade004d808ea1985fa746a7e95ba2a38f867640f53ec8f180bdfe9e2c1268dead7c2664f356e37aba362bc58e46dba03e523a7b5a19e4b6eb12bb02")
+
+	if !PublicKey(public).Verify(hash, sig) {
+		t.Errorf("genesis signature rejected")
+	}
+
+	hash[0] ^= 1
+	if PublicKey(public).Verify(hash, sig) {
+		t.Errorf("signature of different message accepted")
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	bytes, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes
+}
+
 func TestCryptoSigner(t *testing.T) {
 	var zero zeroReader
 	public, private, _ := GenerateKey(zero)
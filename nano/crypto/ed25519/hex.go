@@ -0,0 +1,61 @@
+package ed25519
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+)
+
+// seedSize is the size, in bytes, of the part of a private key that Nano
+// refers to as the private key.
+const seedSize = 32
+
+var (
+	ErrPublicKeyHexLen  = errors.New("ed25519: public key hex strings should be 64 characters long")
+	ErrPrivateKeyHexLen = errors.New("ed25519: private key hex strings should be 64 characters long")
+)
+
+// Hex returns the hex representation of pub.
+func (pub PublicKey) Hex() string {
+	return hex.EncodeToString(pub)
+}
+
+// Hex returns the hex representation of the 32 byte seed of priv, which is
+// what Nano refers to as the private key.
+func (priv PrivateKey) Hex() string {
+	return hex.EncodeToString(priv[:seedSize])
+}
+
+// ParsePublicKeyHex parses the given hex representation of a public key.
+func ParsePublicKeyHex(s string) (PublicKey, error) {
+	if len(s) != hex.EncodedLen(PublicKeySize) {
+		return nil, ErrPublicKeyHexLen
+	}
+
+	key, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return PublicKey(key), nil
+}
+
+// ParsePrivateKeyHex parses the given hex representation of a private key, as
+// returned by PrivateKey.Hex.
+func ParsePrivateKeyHex(s string) (PrivateKey, error) {
+	if len(s) != hex.EncodedLen(seedSize) {
+		return nil, ErrPrivateKeyHexLen
+	}
+
+	seed, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	_, key, err := GenerateKey(bytes.NewReader(seed))
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
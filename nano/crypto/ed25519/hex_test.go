@@ -0,0 +1,49 @@
+package ed25519
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHex(t *testing.T) {
+	// the first key derived from the zero seed
+	privHex := "9f0e444c69f77a49bd0be89db92c38fe713e0963165cca12faf5712d7657120f"
+	pubHex := "c008b814a7d269a1fa3c6528b19201a24d797912db9996ff02a1ff356e45552b"
+
+	for _, s := range []string{privHex, strings.ToUpper(privHex)} {
+		priv, err := ParsePrivateKeyHex(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if priv.Hex() != privHex {
+			t.Errorf("expected: %s, got: %s", privHex, priv.Hex())
+		}
+		if priv.PublicKey().Hex() != pubHex {
+			t.Errorf("expected: %s, got: %s", pubHex, priv.PublicKey().Hex())
+		}
+	}
+
+	for _, s := range []string{pubHex, strings.ToUpper(pubHex)} {
+		pub, err := ParsePublicKeyHex(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(pub, mustDecodeHex(t, pubHex)) {
+			t.Errorf("expected: %s, got: %s", pubHex, pub.Hex())
+		}
+	}
+
+	for _, s := range []string{"", pubHex[1:], pubHex + "00"} {
+		if _, err := ParsePublicKeyHex(s); err != ErrPublicKeyHexLen {
+			t.Errorf("(%s) expected bad length error, got: %v", s, err)
+		}
+		if _, err := ParsePrivateKeyHex(s); err != ErrPrivateKeyHexLen {
+			t.Errorf("(%s) expected bad length error, got: %v", s, err)
+		}
+	}
+
+	if _, err := ParsePublicKeyHex(pubHex[1:] + "x"); err == nil {
+		t.Errorf("expected an error for an invalid hex character")
+	}
+}
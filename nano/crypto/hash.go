@@ -0,0 +1,17 @@
+package crypto
+
+import "golang.org/x/crypto/blake2b"
+
+// Hash returns the blake2b digest of the given size of the concatenation of the
+// given chunks. The size must be between 1 and 64 bytes, otherwise Hash panics.
+func Hash(size int, data ...[]byte) []byte {
+	hash, err := blake2b.New(size, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, chunk := range data {
+		hash.Write(chunk)
+	}
+	return hash.Sum(nil)
+}
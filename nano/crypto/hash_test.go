@@ -0,0 +1,37 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	type hashTest struct {
+		size   int
+		data   [][]byte
+		digest string
+	}
+
+	tests := []hashTest{
+		{64, nil, "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce"},
+		{64, [][]byte{[]byte("abc")}, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		{64, [][]byte{[]byte("a"), nil, []byte("bc")}, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		{32, nil, "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"},
+		{32, [][]byte{[]byte("abc")}, "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"},
+	}
+
+	for i, test := range tests {
+		if res := hex.EncodeToString(Hash(test.size, test.data...)); res != test.digest {
+			t.Errorf("(%d) expected: %s, got: %s", i, test.digest, res)
+		}
+	}
+}
+
+func TestHashBadSize(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected a panic")
+		}
+	}()
+	Hash(65)
+}
@@ -17,6 +17,7 @@ package uint128
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"math/bits"
 
 	"github.com/pkg/errors"
 )
@@ -78,6 +79,23 @@ func (u Uint128) Sub(n Uint128) Uint128 {
 	return Uint128{hi, lo}
 }
 
+// Mul64 returns a new Uint128 multiplied by n. The second return value reports
+// whether the product overflowed 128 bits.
+func (u Uint128) Mul64(n uint64) (Uint128, bool) {
+	carry, lo := bits.Mul64(u.Lo, n)
+	overflow, hi := bits.Mul64(u.Hi, n)
+	hi, c := bits.Add64(hi, carry, 0)
+	return Uint128{hi, lo}, overflow != 0 || c != 0
+}
+
+// DivMod64 returns the quotient and remainder of dividing u by n. It panics if n
+// is zero.
+func (u Uint128) DivMod64(n uint64) (Uint128, uint64) {
+	hi, r := u.Hi/n, u.Hi%n
+	lo, rem := bits.Div64(r, u.Lo, n)
+	return Uint128{hi, lo}, rem
+}
+
 // And returns a new Uint128 that is the bitwise AND of two Uint128 values.
 func (u Uint128) And(o Uint128) Uint128 {
 	return Uint128{u.Hi & o.Hi, u.Lo & o.Lo}
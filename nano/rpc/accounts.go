@@ -0,0 +1,212 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/block"
+)
+
+// AccountInfo holds the information the node has about an account.
+type AccountInfo struct {
+	Frontier            block.Hash
+	OpenBlock           block.Hash
+	RepresentativeBlock block.Hash
+	Balance             nano.Balance
+	Modified            time.Time
+	BlockCount          uint64
+
+	// these are only set when requested with AccountInfoOptions
+	Representative nano.Address
+	Weight         nano.Balance
+	Pending        nano.Balance
+}
+
+// IsOpen reports whether the account has any blocks, that is whether it has a
+// frontier.
+func (info *AccountInfo) IsOpen() bool {
+	return !info.Frontier.IsZero()
+}
+
+// AccountInfoOptions specifies the optional fields to request along with the
+// account info.
+type AccountInfoOptions struct {
+	Representative bool
+	Weight         bool
+	Pending        bool
+
+	// AllowUnopened returns the zero info for accounts that have not been
+	// opened yet, instead of an error matching ErrAccountNotFound.
+	AllowUnopened bool
+}
+
+// AccountBalancePair holds the confirmed and pending balance of an account.
+type AccountBalancePair struct {
+	Balance nano.Balance `json:"balance"`
+	Pending nano.Balance `json:"pending"`
+}
+
+// ReceivableOptions specifies the options for listing receivable blocks.
+type ReceivableOptions struct {
+	// Threshold excludes blocks with an amount smaller than this.
+	Threshold nano.Balance
+}
+
+// ReceivableBlock is a receivable block along with the account that sent it.
+type ReceivableBlock struct {
+	Amount nano.Balance `json:"amount"`
+	Source nano.Address `json:"source"`
+}
+
+// AccountBalance returns the confirmed balance and the pending (receivable)
+// balance of the given account.
+func (c *Client) AccountBalance(ctx context.Context, address nano.Address) (balance nano.Balance, pending nano.Balance, err error) {
+	var res struct {
+		Balance nano.Balance `json:"balance"`
+		Pending nano.Balance `json:"pending"`
+	}
+	params := map[string]interface{}{"account": address}
+	if err = c.Call(ctx, "account_balance", params, &res); err != nil {
+		return nano.ZeroBalance, nano.ZeroBalance, err
+	}
+
+	return res.Balance, res.Pending, nil
+}
+
+// AccountsBalances returns the balances of the given accounts in a single call.
+// Accounts the node has no balance for are absent from the returned map.
+func (c *Client) AccountsBalances(ctx context.Context, addresses []nano.Address) (map[nano.Address]AccountBalancePair, error) {
+	var res struct {
+		Balances map[nano.Address]AccountBalancePair `json:"balances"`
+	}
+	params := map[string]interface{}{"accounts": addresses}
+	if err := c.Call(ctx, "accounts_balances", params, &res); err != nil {
+		return nil, err
+	}
+
+	if res.Balances == nil {
+		res.Balances = make(map[nano.Address]AccountBalancePair)
+	}
+	return res.Balances, nil
+}
+
+// AccountInfo returns information about the given account. The error matches
+// ErrAccountNotFound if the account has not been opened yet, unless
+// opts.AllowUnopened is set.
+func (c *Client) AccountInfo(ctx context.Context, address nano.Address, opts AccountInfoOptions) (*AccountInfo, error) {
+	var res struct {
+		Frontier            block.Hash   `json:"frontier"`
+		OpenBlock           block.Hash   `json:"open_block"`
+		RepresentativeBlock block.Hash   `json:"representative_block"`
+		Balance             nano.Balance `json:"balance"`
+		Modified            int64        `json:"modified_timestamp,string"`
+		BlockCount          uint64       `json:"block_count,string"`
+		Representative      nano.Address `json:"representative"`
+		Weight              nano.Balance `json:"weight"`
+		Pending             nano.Balance `json:"pending"`
+		Receivable          nano.Balance `json:"receivable"`
+	}
+	params := map[string]interface{}{"account": address}
+	if opts.Representative {
+		params["representative"] = "true"
+	}
+	if opts.Weight {
+		params["weight"] = "true"
+	}
+	if opts.Pending {
+		params["pending"] = "true"
+		params["receivable"] = "true"
+	}
+	if err := c.Call(ctx, "account_info", params, &res); err != nil {
+		if opts.AllowUnopened && errors.Is(err, ErrAccountNotFound) {
+			return &AccountInfo{}, nil
+		}
+		return nil, err
+	}
+
+	// newer nodes call pending blocks receivable
+	pending := res.Pending
+	if !res.Receivable.Equal(nano.ZeroBalance) {
+		pending = res.Receivable
+	}
+
+	return &AccountInfo{
+		Frontier:            res.Frontier,
+		OpenBlock:           res.OpenBlock,
+		RepresentativeBlock: res.RepresentativeBlock,
+		Balance:             res.Balance,
+		Modified:            time.Unix(res.Modified, 0),
+		BlockCount:          res.BlockCount,
+		Representative:      res.Representative,
+		Weight:              res.Weight,
+		Pending:             pending,
+	}, nil
+}
+
+// Receivable returns up to count receivable (pending) blocks of the given
+// account, along with their amounts. A zero threshold is sent as 1 raw, because
+// without a threshold the node only lists the hashes of the blocks.
+func (c *Client) Receivable(ctx context.Context, address nano.Address, count int, opts ReceivableOptions) (map[block.Hash]nano.Balance, error) {
+	if opts.Threshold.IsZero() {
+		opts.Threshold = nano.ParseBalanceInts(0, 1)
+	}
+
+	blocks := make(map[block.Hash]nano.Balance)
+	if err := c.receivable(ctx, address, count, opts, false, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// ReceivableSource is like Receivable, but also returns the account that sent
+// each of the blocks.
+func (c *Client) ReceivableSource(ctx context.Context, address nano.Address, count int, opts ReceivableOptions) (map[block.Hash]ReceivableBlock, error) {
+	blocks := make(map[block.Hash]ReceivableBlock)
+	if err := c.receivable(ctx, address, count, opts, true, &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+func (c *Client) receivable(ctx context.Context, address nano.Address, count int, opts ReceivableOptions, source bool, blocks interface{}) error {
+	var res struct {
+		Blocks json.RawMessage `json:"blocks"`
+	}
+	// the node only includes the amounts if a threshold or source is given
+	params := map[string]interface{}{
+		"account":   address,
+		"count":     strconv.Itoa(count),
+		"threshold": opts.Threshold,
+	}
+	if source {
+		params["source"] = "true"
+	}
+	if err := c.Call(ctx, "receivable", params, &res); err != nil {
+		return err
+	}
+
+	// the node returns an empty string instead of an empty object if there are
+	// no receivable blocks
+	if len(res.Blocks) == 0 || string(res.Blocks) == `""` {
+		return nil
+	}
+
+	// a node that ignores the threshold replies with a list of hashes, which
+	// are returned with zero amounts
+	if m, ok := blocks.(*map[block.Hash]nano.Balance); ok && res.Blocks[0] == '[' {
+		var hashes []block.Hash
+		if err := json.Unmarshal(res.Blocks, &hashes); err != nil {
+			return err
+		}
+		for _, hash := range hashes {
+			(*m)[hash] = nano.ZeroBalance
+		}
+		return nil
+	}
+
+	return json.Unmarshal(res.Blocks, blocks)
+}
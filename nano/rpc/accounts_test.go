@@ -0,0 +1,275 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/block"
+)
+
+const (
+	testAccount = "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3"
+)
+
+func mustParseAddress(t *testing.T, s string) nano.Address {
+	address, err := nano.ParseAddress(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return address
+}
+
+func mustParseRaw(t *testing.T, s string) nano.Balance {
+	balance, err := nano.ParseBalance(s, "raw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return balance
+}
+
+func TestClientAccountBalance(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"account_balance": `{
+			"balance": "10000",
+			"pending": "10000000000000000000000000000000000",
+			"receivable": "10000000000000000000000000000000000"
+		}`,
+	})
+
+	balance, pending, err := client.AccountBalance(context.Background(), mustParseAddress(t, testAccount))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !balance.Equal(mustParseRaw(t, "10000")) {
+		t.Errorf("bad balance: %s", balance.UnitString("raw", 0))
+	}
+	if !pending.Equal(mustParseRaw(t, "10000000000000000000000000000000000")) {
+		t.Errorf("bad pending balance: %s", pending.UnitString("raw", 0))
+	}
+
+	if account := (*requests)[0]["account"]; account != testAccount {
+		t.Errorf("bad account in request: %v", account)
+	}
+}
+
+func TestClientAccountsBalances(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"accounts_balances": `{
+			"balances": {
+				"nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3": {
+					"balance": "325586539664609129644855132177",
+					"pending": "2309372032769300000000000000000000",
+					"receivable": "2309372032769300000000000000000000"
+				}
+			},
+			"errors": {
+				"nano_1111111111111111111111111111111111111111111111111111hifc8npp": "Account not found"
+			}
+		}`,
+	})
+
+	funded := mustParseAddress(t, testAccount)
+	unopened := mustParseAddress(t, "nano_1111111111111111111111111111111111111111111111111111hifc8npp")
+	balances, err := client.AccountsBalances(context.Background(), []nano.Address{funded, unopened})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pair, ok := balances[funded]
+	if !ok {
+		t.Fatalf("funded account missing: %v", balances)
+	}
+	if !pair.Balance.Equal(mustParseRaw(t, "325586539664609129644855132177")) || !pair.Pending.Equal(mustParseRaw(t, "2309372032769300000000000000000000")) {
+		t.Errorf("bad balances: %+v", pair)
+	}
+	if _, ok = balances[unopened]; ok || len(balances) != 1 {
+		t.Errorf("expected unopened account to be absent: %v", balances)
+	}
+
+	accounts, ok := (*requests)[0]["accounts"].([]interface{})
+	if !ok || len(accounts) != 2 || accounts[0] != testAccount {
+		t.Fatalf("bad accounts in request: %v", (*requests)[0]["accounts"])
+	}
+}
+
+func TestClientAccountInfo(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"account_info": `{
+			"frontier": "FF84533A571D953A596EA401FD41743AC85D04F406E76FDE4408EAED50B473C5",
+			"open_block": "991CF190094C00F0B68E2E5F75F6BEE95A2E0BD93CEAA4A6734DB9F19B728948",
+			"representative_block": "991CF190094C00F0B68E2E5F75F6BEE95A2E0BD93CEAA4A6734DB9F19B728948",
+			"balance": "235580100176034320859259343606608761791",
+			"modified_timestamp": "1501793775",
+			"block_count": "33",
+			"account_version": "1",
+			"confirmation_height": "28",
+			"representative": "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3",
+			"weight": "1105577030935649664609129644855132177",
+			"receivable": "2309370929000000000000000000000000"
+		}`,
+	})
+
+	opts := AccountInfoOptions{Representative: true, Weight: true, Pending: true}
+	info, err := client.AccountInfo(context.Background(), mustParseAddress(t, testAccount), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Frontier.String() != "ff84533a571d953a596ea401fd41743ac85d04f406e76fde4408eaed50b473c5" {
+		t.Errorf("bad frontier: %s", info.Frontier)
+	}
+	if info.OpenBlock.String() != "991cf190094c00f0b68e2e5f75f6bee95a2e0bd93ceaa4a6734db9f19b728948" || info.RepresentativeBlock != info.OpenBlock {
+		t.Errorf("bad open or representative block: %s, %s", info.OpenBlock, info.RepresentativeBlock)
+	}
+	if !info.Balance.Equal(mustParseRaw(t, "235580100176034320859259343606608761791")) {
+		t.Errorf("bad balance: %s", info.Balance.UnitString("raw", 0))
+	}
+	if info.Modified.Unix() != 1501793775 || info.BlockCount != 33 {
+		t.Errorf("bad modified timestamp or block count: %s, %d", info.Modified, info.BlockCount)
+	}
+	if info.Representative.String() != testAccount {
+		t.Errorf("bad representative: %s", info.Representative)
+	}
+	if !info.Weight.Equal(mustParseRaw(t, "1105577030935649664609129644855132177")) {
+		t.Errorf("bad weight: %s", info.Weight.UnitString("raw", 0))
+	}
+	if !info.Pending.Equal(mustParseRaw(t, "2309370929000000000000000000000000")) {
+		t.Errorf("bad pending balance: %s", info.Pending.UnitString("raw", 0))
+	}
+
+	if !info.IsOpen() {
+		t.Errorf("account should be open")
+	}
+
+	req := (*requests)[0]
+	if req["representative"] != "true" || req["weight"] != "true" || req["pending"] != "true" {
+		t.Errorf("options missing from request: %v", req)
+	}
+}
+
+func TestClientAccountInfoNotFound(t *testing.T) {
+	client, _ := newTestServer(t, map[string]string{
+		"account_info": `{"error": "Account not found"}`,
+	})
+
+	_, err := client.AccountInfo(context.Background(), mustParseAddress(t, testAccount), AccountInfoOptions{})
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("expected account not found error, got: %v", err)
+	}
+
+	info, err := client.AccountInfo(context.Background(), mustParseAddress(t, testAccount), AccountInfoOptions{AllowUnopened: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.IsOpen() || *info != (AccountInfo{}) {
+		t.Fatalf("expected the zero info, got: %+v", info)
+	}
+}
+
+func TestClientReceivable(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"receivable": `{
+			"blocks": {
+				"000D1BAEC8EC208142C99059B393051BAC8380F9B5A2E6B2489A277D81789F3F": "6000000000000000000000000000000"
+			}
+		}`,
+	})
+
+	opts := ReceivableOptions{Threshold: mustParseRaw(t, "1000000000000000000000000")}
+	blocks, err := client.Receivable(context.Background(), mustParseAddress(t, testAccount), 10, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hash block.Hash
+	if err = hash.UnmarshalText([]byte("000d1baec8ec208142c99059b393051bac8380f9b5a2e6b2489a277d81789f3f")); err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 1 || !blocks[hash].Equal(mustParseRaw(t, "6000000000000000000000000000000")) {
+		t.Fatalf("bad blocks: %v", blocks)
+	}
+
+	req := (*requests)[0]
+	if req["threshold"] != "1000000000000000000000000" || req["count"] != "10" {
+		t.Fatalf("bad request: %v", req)
+	}
+}
+
+func TestClientReceivableEmpty(t *testing.T) {
+	client, _ := newTestServer(t, map[string]string{
+		"receivable": `{"blocks": ""}`,
+	})
+
+	blocks, err := client.Receivable(context.Background(), mustParseAddress(t, testAccount), 10, ReceivableOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocks == nil || len(blocks) != 0 {
+		t.Fatalf("expected no blocks, got: %v", blocks)
+	}
+}
+
+func TestClientReceivableDefault(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"receivable": `{
+			"blocks": [
+				"000D1BAEC8EC208142C99059B393051BAC8380F9B5A2E6B2489A277D81789F3F"
+			]
+		}`,
+	})
+
+	blocks, err := client.Receivable(context.Background(), mustParseAddress(t, testAccount), 10, ReceivableOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var hash block.Hash
+	if err = hash.UnmarshalText([]byte("000d1baec8ec208142c99059b393051bac8380f9b5a2e6b2489a277d81789f3f")); err != nil {
+		t.Fatal(err)
+	}
+	amount, ok := blocks[hash]
+	if len(blocks) != 1 || !ok || !amount.IsZero() {
+		t.Fatalf("bad blocks: %v", blocks)
+	}
+
+	if req := (*requests)[0]; req["threshold"] != "1" {
+		t.Fatalf("bad request: %v", req)
+	}
+}
+
+func TestClientReceivableSource(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"receivable": `{
+			"blocks": {
+				"000D1BAEC8EC208142C99059B393051BAC8380F9B5A2E6B2489A277D81789F3F": {
+					"amount": "6000000000000000000000000000000",
+					"source": "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3"
+				}
+			}
+		}`,
+	})
+
+	blocks, err := client.ReceivableSource(context.Background(), mustParseAddress(t, testAccount), 1, ReceivableOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for hash, blk := range blocks {
+		if hash.String() != "000d1baec8ec208142c99059b393051bac8380f9b5a2e6b2489a277d81789f3f" {
+			t.Errorf("bad hash: %s", hash)
+		}
+		if !blk.Amount.Equal(mustParseRaw(t, "6000000000000000000000000000000")) || blk.Source.String() != testAccount {
+			t.Errorf("bad block: %+v", blk)
+		}
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("expected 1 block, got: %d", len(blocks))
+	}
+
+	if req := (*requests)[0]; req["source"] != "true" || req["threshold"] != "0" {
+		t.Fatalf("bad request: %v", req)
+	}
+}
@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/block"
+)
+
+var (
+	ErrBlockSignature = errors.New("block has no valid signature")
+	ErrBlockWork      = errors.New("block work does not meet the threshold")
+)
+
+// BlockInfo holds the information the node has about a block.
+type BlockInfo struct {
+	Account   nano.Address
+	Amount    nano.Balance
+	Balance   nano.Balance
+	Height    uint64
+	Confirmed bool
+	Subtype   block.Subtype
+	Block     *block.StateBlock
+}
+
+// Process publishes the given block to the network and returns its hash. The
+// block must be signed and have work that meets the threshold for its subtype.
+// Rejections by the node are returned as an *RPCError that matches ErrFork,
+// ErrGapPrevious, ErrInsufficientWork or ErrOldBlock where applicable.
+func (c *Client) Process(ctx context.Context, blk *block.StateBlock, subtype block.Subtype) (block.Hash, error) {
+	if !blk.VerifySignature() {
+		return block.Hash{}, ErrBlockSignature
+	}
+	if !block.ValidateWork(blk.Root(), blk.Work, block.ThresholdForSubtype(subtype)) {
+		return block.Hash{}, ErrBlockWork
+	}
+
+	var res struct {
+		Hash block.Hash `json:"hash"`
+	}
+	params := map[string]interface{}{
+		"json_block": "true",
+		"subtype":    subtype.String(),
+		"block":      blk,
+	}
+	if err := c.Call(ctx, "process", params, &res); err != nil {
+		return block.Hash{}, err
+	}
+
+	return res.Hash, nil
+}
+
+// BlockInfo returns information about the state block with the given hash.
+func (c *Client) BlockInfo(ctx context.Context, hash block.Hash) (*BlockInfo, error) {
+	var res struct {
+		BlockAccount nano.Address    `json:"block_account"`
+		Amount       nano.Balance    `json:"amount"`
+		Balance      nano.Balance    `json:"balance"`
+		Height       uint64          `json:"height,string"`
+		Confirmed    bool            `json:"confirmed,string"`
+		Subtype      string          `json:"subtype"`
+		Contents     json.RawMessage `json:"contents"`
+	}
+	params := map[string]interface{}{
+		"json_block": "true",
+		"hash":       hash,
+	}
+	if err := c.Call(ctx, "block_info", params, &res); err != nil {
+		return nil, err
+	}
+
+	subtype, err := block.ParseSubtype(res.Subtype)
+	if err != nil {
+		return nil, err
+	}
+
+	// older nodes ignore json_block and encode the block as a JSON string
+	contents := []byte(res.Contents)
+	if len(contents) > 0 && contents[0] == '"' {
+		var s string
+		if err = json.Unmarshal(contents, &s); err != nil {
+			return nil, err
+		}
+		contents = []byte(s)
+	}
+
+	blk := new(block.StateBlock)
+	if err = json.Unmarshal(contents, blk); err != nil {
+		return nil, err
+	}
+
+	return &BlockInfo{
+		Account:   res.BlockAccount,
+		Amount:    res.Amount,
+		Balance:   res.Balance,
+		Height:    res.Height,
+		Confirmed: res.Confirmed,
+		Subtype:   subtype,
+		Block:     blk,
+	}, nil
+}
@@ -0,0 +1,165 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/block"
+	"littleriver.cc/go-nano/nano/crypto/ed25519"
+	"littleriver.cc/go-nano/nano/internal/util"
+)
+
+// newTestBlock returns a signed receive block with valid work.
+func newTestBlock(t *testing.T) *block.StateBlock {
+	var zero [32]byte
+	pub, key, err := ed25519.GenerateKey(bytes.NewReader(zero[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blk := &block.StateBlock{
+		Address:        nano.EncodeAddress(pub),
+		PreviousHash:   util.MustDecodeHex32("f47b23107e5f34b2ce06f562b5c435df72a533251cb414c51b2b62a8f63a00e4"),
+		Representative: mustParseAddress(t, testAccount),
+		Balance:        nano.ParseBalanceInts(0, 1),
+		Link:           util.MustDecodeHex32("19d3d919475deed4696b5d13018151d1af88b2bd3bcff048b45031c1f36d1858"),
+		Work:           0xcab7404f0b5449d0,
+	}
+	if err = blk.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+	return blk
+}
+
+func TestClientProcess(t *testing.T) {
+	blk := newTestBlock(t)
+	hash := blk.Hash()
+	client, requests := newTestServer(t, map[string]string{
+		"process": `{"hash": "` + hash.String() + `"}`,
+	})
+
+	res, err := client.Process(context.Background(), blk, block.SubtypeReceive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != hash {
+		t.Fatalf("expected: %s, got: %s", hash, res)
+	}
+
+	req := (*requests)[0]
+	if req["subtype"] != "receive" || req["json_block"] != "true" {
+		t.Fatalf("bad request: %v", req)
+	}
+	data, ok := req["block"].(map[string]interface{})
+	if !ok || data["type"] != "state" || data["account"] != blk.Address.String() {
+		t.Fatalf("bad block in request: %v", req["block"])
+	}
+}
+
+func TestClientProcessRejected(t *testing.T) {
+	tests := map[string]error{
+		"Fork":                              ErrFork,
+		"Gap previous block":                ErrGapPrevious,
+		"Block work is less than threshold": ErrInsufficientWork,
+		"Old block":                         ErrOldBlock,
+	}
+
+	blk := newTestBlock(t)
+	for msg, expected := range tests {
+		client, _ := newTestServer(t, map[string]string{
+			"process": `{"error": "` + msg + `"}`,
+		})
+
+		if _, err := client.Process(context.Background(), blk, block.SubtypeReceive); !errors.Is(err, expected) {
+			t.Errorf("(%s) expected: %v, got: %v", msg, expected, err)
+		}
+	}
+}
+
+func TestClientProcessInvalid(t *testing.T) {
+	client, requests := newTestServer(t, nil)
+
+	// the work only meets the receive threshold
+	blk := newTestBlock(t)
+	if _, err := client.Process(context.Background(), blk, block.SubtypeSend); err != ErrBlockWork {
+		t.Errorf("expected work error, got: %v", err)
+	}
+
+	blk.Balance = nano.ParseBalanceInts(0, 2)
+	if _, err := client.Process(context.Background(), blk, block.SubtypeReceive); err != ErrBlockSignature {
+		t.Errorf("expected signature error, got: %v", err)
+	}
+
+	if len(*requests) != 0 {
+		t.Fatalf("invalid blocks were sent to the node")
+	}
+}
+
+// testBlockContents is a state block from the live network, in the format of
+// the node.
+const testBlockContents = `{
+	"type": "state",
+	"account": "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr",
+	"previous": "F47B23107E5F34B2CE06F562B5C435DF72A533251CB414C51B2B62A8F63A00E4",
+	"representative": "nano_1hza3f7wiiqa7ig3jczyxj5yo86yegcmqk3criaz838j91sxcckpfhbhhra1",
+	"balance": "1000000000000000000000",
+	"link": "19D3D919475DEED4696B5D13018151D1AF88B2BD3BCFF048B45031C1F36D1858",
+	"link_as_account": "nano_18gmu6engqhgtjnppqam181o5nfhj4sdtgyhy36dan3jr9spt84rzwmktafc",
+	"signature": "3BFBA64A775550E6D49DF1EB8EEC2136DCD74F090E2ED658FBD9E80F17CB1C9F9F7BDE2B93D95558EC2F277FFF15FD11E6E2162A1714731B743D1E941FA4560A",
+	"work": "cab7404f0b5449d0"
+}`
+
+func TestClientBlockInfo(t *testing.T) {
+	encoded, err := json.Marshal(testBlockContents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// newer nodes embed the block as an object, older ones as a string
+	for _, contents := range []string{testBlockContents, string(encoded)} {
+		client, requests := newTestServer(t, map[string]string{
+			"block_info": `{
+				"block_account": "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr",
+				"amount": "30000000000000000000000000000000000",
+				"balance": "1000000000000000000000",
+				"height": "58",
+				"local_timestamp": "0",
+				"successor": "0000000000000000000000000000000000000000000000000000000000000000",
+				"confirmed": "true",
+				"contents": ` + contents + `,
+				"subtype": "send"
+			}`,
+		})
+
+		hash := block.Hash(util.MustDecodeHex32("ff0144381cff0b2c079a115e7ada7e96f43fd219446e7524c48d1cc9900c4f17"))
+		info, err := client.BlockInfo(context.Background(), hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if info.Account.String() != "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr" {
+			t.Errorf("bad account: %s", info.Account)
+		}
+		if !info.Amount.Equal(mustParseRaw(t, "30000000000000000000000000000000000")) {
+			t.Errorf("bad amount: %s", info.Amount.UnitString("raw", 0))
+		}
+		if !info.Balance.Equal(mustParseRaw(t, "1000000000000000000000")) || info.Height != 58 {
+			t.Errorf("bad balance or height: %s, %d", info.Balance.UnitString("raw", 0), info.Height)
+		}
+		if !info.Confirmed || info.Subtype != block.SubtypeSend {
+			t.Errorf("bad confirmed flag or subtype: %t, %d", info.Confirmed, info.Subtype)
+		}
+		if info.Block.Hash() != hash || !info.Block.VerifySignature() {
+			t.Errorf("bad block: %s", info.Block.Hash())
+		}
+
+		req := (*requests)[0]
+		if req["hash"] != hash.String() || req["json_block"] != "true" {
+			t.Fatalf("bad request: %v", req)
+		}
+	}
+}
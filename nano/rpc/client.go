@@ -0,0 +1,229 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// DefaultBackoffBase is the delay before the first retry if
+// ClientOptions.BackoffBase is not set.
+const DefaultBackoffBase = 100 * time.Millisecond
+
+var (
+	ErrAccountNotFound  = errors.New("account not found")
+	ErrFork             = errors.New("block is a fork")
+	ErrGapPrevious      = errors.New("previous block is unknown")
+	ErrInsufficientWork = errors.New("block work is insufficient")
+	ErrOldBlock         = errors.New("block is already known")
+
+	// nodeErrors maps the error messages of the node to their sentinel errors.
+	nodeErrors = map[string]error{
+		"Account not found":                 ErrAccountNotFound,
+		"Fork":                              ErrFork,
+		"Gap previous block":                ErrGapPrevious,
+		"Block work is less than threshold": ErrInsufficientWork,
+		"Old block":                         ErrOldBlock,
+		"Cannot generate work":              ErrWorkNotFound,
+		"Work generation cancellation or failure": ErrWorkNotFound,
+	}
+
+	// nonIdempotentActions are the actions that are not retried, unless
+	// ClientOptions.RetryAll is set.
+	nonIdempotentActions = map[string]bool{
+		"process": true,
+		"send":    true,
+		"receive": true,
+	}
+)
+
+// RPCError is an error reported by the node. Known errors match their sentinel
+// error, like ErrFork, when compared with errors.Is.
+type RPCError struct {
+	// Message is the error message returned by the node.
+	Message string
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("node error: %s", e.Message)
+}
+
+// Is reports whether the error message of the node corresponds to the given
+// sentinel error.
+func (e *RPCError) Is(target error) bool {
+	err, ok := nodeErrors[e.Message]
+	return ok && err == target
+}
+
+// IsAccountNotFound reports whether the account is not known to the node.
+func (e *RPCError) IsAccountNotFound() bool {
+	return e.Is(ErrAccountNotFound)
+}
+
+// IsFork reports whether the block was rejected as a fork.
+func (e *RPCError) IsFork() bool {
+	return e.Is(ErrFork)
+}
+
+// IsGapPrevious reports whether the block was rejected because its previous
+// block is not known to the node.
+func (e *RPCError) IsGapPrevious() bool {
+	return e.Is(ErrGapPrevious)
+}
+
+// IsInsufficientWork reports whether the block was rejected because its work
+// does not meet the threshold.
+func (e *RPCError) IsInsufficientWork() bool {
+	return e.Is(ErrInsufficientWork)
+}
+
+// IsOldBlock reports whether the block was rejected because it is already
+// known to the node.
+func (e *RPCError) IsOldBlock() bool {
+	return e.Is(ErrOldBlock)
+}
+
+// Client is a client for the RPC interface of a Nano node.
+type Client struct {
+	url  string
+	http *http.Client
+	opts ClientOptions
+}
+
+// ClientOptions configures the timeouts and retries of a Client.
+type ClientOptions struct {
+	// Timeout limits the duration of every request to the node. A shorter
+	// deadline of the context passed to a call still applies. There is no
+	// timeout if it is zero.
+	Timeout time.Duration
+	// MaxRetries is the number of times a request is retried after a network
+	// error or a 5xx response from the server.
+	MaxRetries int
+	// BackoffBase is the delay before the first retry, that is doubled for
+	// every following retry. It defaults to DefaultBackoffBase.
+	BackoffBase time.Duration
+	// RetryAll also enables retries for actions that are not idempotent, like
+	// process.
+	RetryAll bool
+}
+
+// NewClient creates a new client for the node RPC server at the given URL. If
+// client is nil, http.DefaultClient is used.
+func NewClient(url string, client *http.Client) *Client {
+	return NewClientWithOptions(url, client, ClientOptions{})
+}
+
+// NewClientWithOptions is like NewClient, but configures the timeouts and
+// retries of the client using the given options.
+func NewClientWithOptions(url string, client *http.Client, opts ClientOptions) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if opts.BackoffBase == 0 {
+		opts.BackoffBase = DefaultBackoffBase
+	}
+
+	return &Client{
+		url:  url,
+		http: client,
+		opts: opts,
+	}
+}
+
+// Call performs the given RPC action with the given parameters and decodes the
+// response into out, which may be nil. Errors reported by the node are returned
+// as an *RPCError. Timeouts and cancellation are driven by the context and the
+// options of the client.
+func (c *Client) Call(ctx context.Context, action string, params map[string]interface{}, out interface{}) error {
+	body := map[string]interface{}{"action": action}
+	for key, value := range params {
+		body[key] = value
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	retries := c.opts.MaxRetries
+	if nonIdempotentActions[action] && !c.opts.RetryAll {
+		retries = 0
+	}
+
+	backoff := c.opts.BackoffBase
+	for attempt := 0; ; attempt++ {
+		res, transient, postErr := c.post(ctx, data)
+		if postErr == nil || !transient || attempt >= retries {
+			data, err = res, postErr
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+	if err != nil {
+		return err
+	}
+
+	// the node reports errors with a regular status code
+	var resErr struct {
+		Error string `json:"error"`
+	}
+	if err = json.Unmarshal(data, &resErr); err != nil {
+		return err
+	}
+	if resErr.Error != "" {
+		return &RPCError{Message: resErr.Error}
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// post sends the given request body to the node and returns the response body.
+// It reports whether a failure is transient and the request may be retried.
+func (c *Client) post(ctx context.Context, body []byte) ([]byte, bool, error) {
+	reqCtx := ctx
+	if c.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, c.opts.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		// there is no point in retrying if the caller gave up
+		return nil, ctx.Err() == nil, err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, res.StatusCode >= 500, fmt.Errorf("unexpected http status: %s", res.Status)
+	}
+
+	return data, false, nil
+}
@@ -0,0 +1,244 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer starts a server that records the request body of each call and
+// replies with the response for its action.
+func newTestServer(t *testing.T, responses map[string]string) (*Client, *[]map[string]interface{}) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the handler runs on the server's goroutine, where t.Fatal is not
+		// allowed
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var req map[string]interface{}
+		if err = json.Unmarshal(data, &req); err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		requests = append(requests, req)
+
+		action, _ := req["action"].(string)
+		res, ok := responses[action]
+		if !ok {
+			res = `{"error": "Unknown command"}`
+		}
+		w.Write([]byte(res))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(server.URL, server.Client()), &requests
+}
+
+func TestClientCall(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"block_count": `{"count": "1000", "unchecked": "10", "cemented": "25"}`,
+	})
+
+	var res struct {
+		Count     string `json:"count"`
+		Unchecked string `json:"unchecked"`
+	}
+	params := map[string]interface{}{"include_cemented": "true"}
+	if err := client.Call(context.Background(), "block_count", params, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != "1000" || res.Unchecked != "10" {
+		t.Fatalf("bad response: %+v", res)
+	}
+
+	req := (*requests)[0]
+	if req["action"] != "block_count" || req["include_cemented"] != "true" {
+		t.Fatalf("bad request: %v", req)
+	}
+}
+
+func TestClientCallError(t *testing.T) {
+	client, _ := newTestServer(t, nil)
+
+	err := client.Call(context.Background(), "bad_action", nil, nil)
+	if err == nil || err.Error() != "node error: Unknown command" {
+		t.Fatalf("expected node error, got: %v", err)
+	}
+}
+
+func TestClientCallRPCError(t *testing.T) {
+	tests := map[string]func(*RPCError) bool{
+		"Account not found":                 (*RPCError).IsAccountNotFound,
+		"Fork":                              (*RPCError).IsFork,
+		"Gap previous block":                (*RPCError).IsGapPrevious,
+		"Block work is less than threshold": (*RPCError).IsInsufficientWork,
+		"Old block":                         (*RPCError).IsOldBlock,
+	}
+	predicates := []func(*RPCError) bool{
+		(*RPCError).IsAccountNotFound,
+		(*RPCError).IsFork,
+		(*RPCError).IsGapPrevious,
+		(*RPCError).IsInsufficientWork,
+		(*RPCError).IsOldBlock,
+	}
+
+	for msg, expected := range tests {
+		client, _ := newTestServer(t, map[string]string{
+			"account_info": `{"error": "` + msg + `"}`,
+		})
+
+		err := client.Call(context.Background(), "account_info", nil, nil)
+		var rpcErr *RPCError
+		if !errors.As(err, &rpcErr) || rpcErr.Message != msg {
+			t.Fatalf("(%s) expected rpc error, got: %v", msg, err)
+		}
+
+		// exactly one predicate should match
+		matches := 0
+		for _, predicate := range predicates {
+			if predicate(rpcErr) {
+				matches++
+			}
+		}
+		if !expected(rpcErr) || matches != 1 {
+			t.Errorf("(%s) predicate mismatch", msg)
+		}
+	}
+
+	rpcErr := &RPCError{Message: "Unknown command"}
+	for _, predicate := range predicates {
+		if predicate(rpcErr) {
+			t.Errorf("unknown error should not match any predicate")
+		}
+	}
+	if errors.Is(rpcErr, ErrFork) || errors.Is(rpcErr, nil) {
+		t.Errorf("unknown error should not match any sentinel")
+	}
+}
+
+func TestClientCallTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	client := NewClient(server.URL, nil)
+	if err := client.Call(ctx, "block_count", nil, nil); err == nil {
+		t.Fatalf("expected timeout error")
+	}
+}
+
+// newFlakyServer starts a server that fails the first given number of requests
+// with a 503 status and replies with response afterwards.
+func newFlakyServer(t *testing.T, failures int32, response string) (*httptest.Server, *int32) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &attempts
+}
+
+func TestClientCallRetry(t *testing.T) {
+	server, attempts := newFlakyServer(t, 1, `{"count": "1000"}`)
+	client := NewClientWithOptions(server.URL, server.Client(), ClientOptions{
+		MaxRetries:  3,
+		BackoffBase: time.Millisecond,
+	})
+
+	var res struct {
+		Count string `json:"count"`
+	}
+	if err := client.Call(context.Background(), "block_count", nil, &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Count != "1000" {
+		t.Fatalf("bad response: %+v", res)
+	}
+	if *attempts != 2 {
+		t.Fatalf("expected 2 attempts, got: %d", *attempts)
+	}
+}
+
+func TestClientCallRetryExhausted(t *testing.T) {
+	server, attempts := newFlakyServer(t, 5, `{}`)
+	client := NewClientWithOptions(server.URL, server.Client(), ClientOptions{
+		MaxRetries:  2,
+		BackoffBase: time.Millisecond,
+	})
+
+	if err := client.Call(context.Background(), "block_count", nil, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if *attempts != 3 {
+		t.Fatalf("expected 3 attempts, got: %d", *attempts)
+	}
+}
+
+func TestClientCallRetryProcess(t *testing.T) {
+	server, attempts := newFlakyServer(t, 1, `{"hash": "0"}`)
+	opts := ClientOptions{MaxRetries: 3, BackoffBase: time.Millisecond}
+
+	client := NewClientWithOptions(server.URL, server.Client(), opts)
+	if err := client.Call(context.Background(), "process", nil, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if *attempts != 1 {
+		t.Fatalf("expected 1 attempt, got: %d", *attempts)
+	}
+
+	opts.RetryAll = true
+	client = NewClientWithOptions(server.URL, server.Client(), opts)
+	if err := client.Call(context.Background(), "process", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientCallOptionsTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	client := NewClientWithOptions(server.URL, nil, ClientOptions{Timeout: 10 * time.Millisecond})
+	if err := client.Call(context.Background(), "block_count", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+
+	// a shorter context deadline takes precedence
+	client = NewClientWithOptions(server.URL, nil, ClientOptions{Timeout: time.Hour, MaxRetries: 3})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := client.Call(ctx, "block_count", nil, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("call did not honor the context deadline")
+	}
+}
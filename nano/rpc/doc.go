@@ -0,0 +1,3 @@
+// Package rpc provides a client for the JSON based RPC interface of the Nano
+// reference node.
+package rpc
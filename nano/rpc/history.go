@@ -0,0 +1,114 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/block"
+)
+
+// HistoryOptions specifies the options for listing the history of an account.
+type HistoryOptions struct {
+	// Raw includes the change and epoch blocks in the history and fills in the
+	// block fields of the entries.
+	Raw bool
+	// Reverse lists the history from the oldest block onwards.
+	Reverse bool
+	// Offset skips the given number of blocks.
+	Offset int
+}
+
+// HistoryEntry is a block in the history of an account.
+type HistoryEntry struct {
+	Type block.Subtype
+	// Account is the counterparty of a send or receive block.
+	Account        nano.Address
+	Amount         nano.Balance
+	Hash           block.Hash
+	Height         uint64
+	LocalTimestamp time.Time
+
+	// these are only set for state blocks when requested with HistoryOptions.Raw
+	Representative nano.Address
+	Balance        nano.Balance
+	Previous       block.Hash
+	Link           block.Hash
+}
+
+// AccountHistory returns up to count blocks of the history of the given account,
+// starting at its frontier.
+func (c *Client) AccountHistory(ctx context.Context, address nano.Address, count int, opts HistoryOptions) ([]HistoryEntry, error) {
+	var res struct {
+		History json.RawMessage `json:"history"`
+	}
+	params := map[string]interface{}{
+		"account": address,
+		"count":   strconv.Itoa(count),
+	}
+	if opts.Raw {
+		params["raw"] = "true"
+	}
+	if opts.Reverse {
+		params["reverse"] = "true"
+	}
+	if opts.Offset > 0 {
+		params["offset"] = strconv.Itoa(opts.Offset)
+	}
+	if err := c.Call(ctx, "account_history", params, &res); err != nil {
+		return nil, err
+	}
+
+	// the node returns an empty string instead of an empty list if the account
+	// has no history
+	if len(res.History) == 0 || string(res.History) == `""` {
+		return nil, nil
+	}
+
+	var entries []struct {
+		Type           string       `json:"type"`
+		Subtype        string       `json:"subtype"`
+		Account        nano.Address `json:"account"`
+		Amount         nano.Balance `json:"amount"`
+		Hash           block.Hash   `json:"hash"`
+		Height         uint64       `json:"height,string"`
+		LocalTimestamp int64        `json:"local_timestamp,string"`
+		Representative nano.Address `json:"representative"`
+		Balance        nano.Balance `json:"balance"`
+		Previous       block.Hash   `json:"previous"`
+		Link           block.Hash   `json:"link"`
+	}
+	if err := json.Unmarshal(res.History, &entries); err != nil {
+		return nil, err
+	}
+
+	history := make([]HistoryEntry, len(entries))
+	for i, entry := range entries {
+		// raw state blocks carry their type in the subtype field
+		name := entry.Type
+		if name == "state" {
+			name = entry.Subtype
+		}
+		subtype, err := block.ParseSubtype(name)
+		if err != nil {
+			return nil, err
+		}
+
+		history[i] = HistoryEntry{
+			Type:           subtype,
+			Account:        entry.Account,
+			Amount:         entry.Amount,
+			Hash:           entry.Hash,
+			Height:         entry.Height,
+			LocalTimestamp: time.Unix(entry.LocalTimestamp, 0),
+			Representative: entry.Representative,
+			Balance:        entry.Balance,
+			Previous:       entry.Previous,
+			Link:           entry.Link,
+		}
+	}
+
+	return history, nil
+}
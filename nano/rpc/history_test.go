@@ -0,0 +1,195 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"littleriver.cc/go-nano/nano/block"
+)
+
+// historyPages holds the account history in pages of two blocks, keyed by the
+// offset of the page.
+var historyPages = map[string]string{
+	"": `{
+		"account": "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3",
+		"history": [
+			{
+				"type": "send",
+				"account": "nano_18gmu6engqhgtjnppqam181o5nfhj4sdtgyhy36dan3jr9spt84rzwmktafc",
+				"amount": "80000000000000000000000000000000000",
+				"local_timestamp": "1551532723",
+				"height": "60",
+				"hash": "80392607E85E73CC3E94B4126F24488EBDFEB174944B890C97E8F36D89591DC5"
+			},
+			{
+				"type": "receive",
+				"account": "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr",
+				"amount": "1000000000000000000000",
+				"local_timestamp": "1551532500",
+				"height": "59",
+				"hash": "FF0144381CFF0B2C079A115E7ADA7E96F43FD219446E7524C48D1CC9900C4F17"
+			}
+		],
+		"previous": "991CF190094C00F0B68E2E5F75F6BEE95A2E0BD93CEAA4A6734DB9F19B728948"
+	}`,
+	"2": `{
+		"account": "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3",
+		"history": [
+			{
+				"type": "receive",
+				"account": "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3",
+				"amount": "340282366920938463463374607431768211455",
+				"local_timestamp": "0",
+				"height": "1",
+				"hash": "991CF190094C00F0B68E2E5F75F6BEE95A2E0BD93CEAA4A6734DB9F19B728948"
+			}
+		]
+	}`,
+	"4": `{
+		"account": "nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3",
+		"history": ""
+	}`,
+}
+
+func newHistoryServer(t *testing.T) (*Client, *[]map[string]interface{}) {
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var req map[string]interface{}
+		if err = json.Unmarshal(data, &req); err != nil {
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		requests = append(requests, req)
+
+		offset, _ := req["offset"].(string)
+		w.Write([]byte(historyPages[offset]))
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(server.URL, server.Client()), &requests
+}
+
+func TestClientAccountHistory(t *testing.T) {
+	client, requests := newHistoryServer(t)
+	address := mustParseAddress(t, testAccount)
+
+	var history []HistoryEntry
+	for offset := 0; ; offset += 2 {
+		page, err := client.AccountHistory(context.Background(), address, 2, HistoryOptions{Offset: offset})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		history = append(history, page...)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 entries, got: %d", len(history))
+	}
+
+	send := history[0]
+	if send.Type != block.SubtypeSend || send.Account.String() != "nano_18gmu6engqhgtjnppqam181o5nfhj4sdtgyhy36dan3jr9spt84rzwmktafc" {
+		t.Errorf("bad send entry: %+v", send)
+	}
+	if !send.Amount.Equal(mustParseRaw(t, "80000000000000000000000000000000000")) {
+		t.Errorf("bad amount: %s", send.Amount.UnitString("raw", 0))
+	}
+	if send.Height != 60 || send.LocalTimestamp.Unix() != 1551532723 {
+		t.Errorf("bad height or timestamp: %d, %s", send.Height, send.LocalTimestamp)
+	}
+	if send.Hash.String() != "80392607e85e73cc3e94b4126f24488ebdfeb174944b890c97e8f36d89591dc5" {
+		t.Errorf("bad hash: %s", send.Hash)
+	}
+
+	if history[1].Type != block.SubtypeReceive || history[2].Type != block.SubtypeReceive {
+		t.Errorf("expected receive entries, got: %d, %d", history[1].Type, history[2].Type)
+	}
+	if history[2].Height != 1 || history[2].Hash.String() != "991cf190094c00f0b68e2e5f75f6bee95a2e0bd93ceaa4a6734db9f19b728948" {
+		t.Errorf("bad genesis entry: %+v", history[2])
+	}
+
+	req := (*requests)[1]
+	if req["account"] != testAccount || req["count"] != "2" || req["offset"] != "2" {
+		t.Fatalf("bad request: %v", req)
+	}
+	if _, ok := (*requests)[0]["offset"]; ok {
+		t.Fatalf("unexpected offset in request: %v", (*requests)[0])
+	}
+}
+
+func TestClientAccountHistoryRaw(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"account_history": `{
+			"account": "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr",
+			"history": [
+				{
+					"type": "state",
+					"representative": "nano_1hza3f7wiiqa7ig3jczyxj5yo86yegcmqk3criaz838j91sxcckpfhbhhra1",
+					"link": "19D3D919475DEED4696B5D13018151D1AF88B2BD3BCFF048B45031C1F36D1858",
+					"balance": "1000000000000000000000",
+					"previous": "F47B23107E5F34B2CE06F562B5C435DF72A533251CB414C51B2B62A8F63A00E4",
+					"subtype": "send",
+					"account": "nano_18gmu6engqhgtjnppqam181o5nfhj4sdtgyhy36dan3jr9spt84rzwmktafc",
+					"amount": "2000000000000000000000",
+					"local_timestamp": "1551532723",
+					"height": "3",
+					"hash": "FF0144381CFF0B2C079A115E7ADA7E96F43FD219446E7524C48D1CC9900C4F17"
+				},
+				{
+					"type": "state",
+					"representative": "nano_1hza3f7wiiqa7ig3jczyxj5yo86yegcmqk3criaz838j91sxcckpfhbhhra1",
+					"link": "0000000000000000000000000000000000000000000000000000000000000000",
+					"balance": "3000000000000000000000",
+					"previous": "991CF190094C00F0B68E2E5F75F6BEE95A2E0BD93CEAA4A6734DB9F19B728948",
+					"subtype": "change",
+					"account": "nano_1111111111111111111111111111111111111111111111111111hifc8npp",
+					"amount": "0",
+					"local_timestamp": "1551532000",
+					"height": "2",
+					"hash": "F47B23107E5F34B2CE06F562B5C435DF72A533251CB414C51B2B62A8F63A00E4"
+				}
+			]
+		}`,
+	})
+
+	address := mustParseAddress(t, "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr")
+	history, err := client.AccountHistory(context.Background(), address, 2, HistoryOptions{Raw: true, Reverse: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 entries, got: %d", len(history))
+	}
+
+	send := history[0]
+	if send.Type != block.SubtypeSend || send.Link.AsAddress() != send.Account {
+		t.Errorf("bad send entry: %+v", send)
+	}
+	if send.Representative.String() != "nano_1hza3f7wiiqa7ig3jczyxj5yo86yegcmqk3criaz838j91sxcckpfhbhhra1" {
+		t.Errorf("bad representative: %s", send.Representative)
+	}
+	if !send.Balance.Equal(mustParseRaw(t, "1000000000000000000000")) || send.Previous != history[1].Hash {
+		t.Errorf("bad balance or previous: %s, %s", send.Balance.UnitString("raw", 0), send.Previous)
+	}
+	if history[1].Type != block.SubtypeChange || !history[1].Amount.Equal(mustParseRaw(t, "0")) {
+		t.Errorf("bad change entry: %+v", history[1])
+	}
+
+	req := (*requests)[0]
+	if req["raw"] != "true" || req["reverse"] != "true" {
+		t.Fatalf("bad request: %v", req)
+	}
+}
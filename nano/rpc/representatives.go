@@ -0,0 +1,65 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strconv"
+
+	"littleriver.cc/go-nano/nano"
+)
+
+// RepresentativesOptions specifies the options for listing representatives.
+type RepresentativesOptions struct {
+	// MinWeight excludes representatives with a weight smaller than this.
+	MinWeight nano.Balance
+}
+
+// RepresentativeWeight is a representative along with its voting weight.
+type RepresentativeWeight struct {
+	Address nano.Address
+	Weight  nano.Balance
+}
+
+// Representatives returns the representatives known to the node along with
+// their voting weight. If count is positive, only the count representatives with
+// the largest weight are returned.
+func (c *Client) Representatives(ctx context.Context, count int, opts RepresentativesOptions) (map[nano.Address]nano.Balance, error) {
+	var res struct {
+		Representatives map[nano.Address]nano.Balance `json:"representatives"`
+	}
+	params := map[string]interface{}{}
+	if count > 0 {
+		// the node only returns the heaviest representatives when sorting
+		params["count"] = strconv.Itoa(count)
+		params["sorting"] = "true"
+	}
+	if err := c.Call(ctx, "representatives", params, &res); err != nil {
+		return nil, err
+	}
+
+	reps := make(map[nano.Address]nano.Balance, len(res.Representatives))
+	for address, weight := range res.Representatives {
+		if weight.Cmp(opts.MinWeight) >= 0 {
+			reps[address] = weight
+		}
+	}
+	return reps, nil
+}
+
+// SortRepresentatives returns the given representatives sorted by descending
+// weight. Representatives with the same weight are sorted by address.
+func SortRepresentatives(reps map[nano.Address]nano.Balance) []RepresentativeWeight {
+	sorted := make([]RepresentativeWeight, 0, len(reps))
+	for address, weight := range reps {
+		sorted = append(sorted, RepresentativeWeight{Address: address, Weight: weight})
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if cmp := sorted[i].Weight.Cmp(sorted[j].Weight); cmp != 0 {
+			return cmp > 0
+		}
+		return bytes.Compare(sorted[i].Address[:], sorted[j].Address[:]) < 0
+	})
+	return sorted
+}
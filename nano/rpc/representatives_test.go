@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"littleriver.cc/go-nano/nano"
+)
+
+func TestClientRepresentatives(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"representatives": `{
+			"representatives": {
+				"nano_1hza3f7wiiqa7ig3jczyxj5yo86yegcmqk3criaz838j91sxcckpfhbhhra1": "3822372327060170000000000000000000000",
+				"nano_3t6k35gi95xu6tergt6p69ck76ogmitsa8mnijtpxm9fkcm736xtoncuohr3": "133248297000000000000000000000000000000",
+				"nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr": "569965000000000000000000000",
+				"nano_18gmu6engqhgtjnppqam181o5nfhj4sdtgyhy36dan3jr9spt84rzwmktafc": "12"
+			}
+		}`,
+	})
+
+	opts := RepresentativesOptions{MinWeight: mustParseRaw(t, "1000000000000000000000000")}
+	reps, err := client.Representatives(context.Background(), 10, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reps) != 3 {
+		t.Fatalf("expected 3 representatives, got: %d", len(reps))
+	}
+
+	sorted := SortRepresentatives(reps)
+	expected := []string{
+		testAccount,
+		"nano_1hza3f7wiiqa7ig3jczyxj5yo86yegcmqk3criaz838j91sxcckpfhbhhra1",
+		"nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr",
+	}
+	for i, rep := range sorted {
+		if rep.Address.String() != expected[i] {
+			t.Errorf("(%d) expected: %s, got: %s", i, expected[i], rep.Address)
+		}
+		if !rep.Weight.Equal(reps[rep.Address]) {
+			t.Errorf("(%d) bad weight: %s", i, rep.Weight.UnitString("raw", 0))
+		}
+	}
+
+	req := (*requests)[0]
+	if req["count"] != "10" || req["sorting"] != "true" {
+		t.Fatalf("bad request: %v", req)
+	}
+}
+
+func TestSortRepresentativesTies(t *testing.T) {
+	weight := nano.ParseBalanceInts(0, 100)
+	reps := map[nano.Address]nano.Balance{
+		{2}: weight,
+		{1}: weight,
+		{3}: nano.ParseBalanceInts(0, 200),
+	}
+
+	sorted := SortRepresentatives(reps)
+	for i, expected := range []nano.Address{{3}, {1}, {2}} {
+		if sorted[i].Address != expected {
+			t.Errorf("(%d) expected: %s, got: %s", i, expected, sorted[i].Address)
+		}
+	}
+}
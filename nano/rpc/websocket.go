@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"golang.org/x/net/websocket"
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/block"
+)
+
+// WSClient is a client for the WebSocket interface of a Nano node.
+type WSClient struct {
+	url    string
+	origin string
+}
+
+// ConfirmationEvent is sent by the node when a block has been confirmed.
+type ConfirmationEvent struct {
+	Hash    block.Hash
+	Account nano.Address
+	Amount  nano.Balance
+	Block   *block.StateBlock
+}
+
+// NewWSClient creates a new client for the node WebSocket server at the given
+// URL, for example ws://localhost:7078.
+func NewWSClient(url string) *WSClient {
+	return &WSClient{
+		url:    url,
+		origin: "http://localhost/",
+	}
+}
+
+// SubscribeConfirmations subscribes to confirmations of blocks of the given
+// accounts, or of all blocks if no accounts are given. Events are delivered on
+// the returned channel, which is closed once the context is done or the
+// connection is lost.
+func (c *WSClient) SubscribeConfirmations(ctx context.Context, accounts []nano.Address) (<-chan ConfirmationEvent, error) {
+	config, err := websocket.NewConfig(c.url, c.origin)
+	if err != nil {
+		return nil, err
+	}
+	config.Dialer = new(net.Dialer)
+	if deadline, ok := ctx.Deadline(); ok {
+		config.Dialer.Deadline = deadline
+	}
+
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribe := map[string]interface{}{
+		"action": "subscribe",
+		"topic":  "confirmation",
+	}
+	if len(accounts) > 0 {
+		subscribe["options"] = map[string]interface{}{"accounts": accounts}
+	}
+	if err = websocket.JSON.Send(conn, subscribe); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	events := make(chan ConfirmationEvent)
+	done := make(chan struct{})
+	go func() {
+		// closing the connection unblocks the receive loop below
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		conn.Close()
+	}()
+
+	go func() {
+		defer close(events)
+		defer close(done)
+
+		for {
+			var msg struct {
+				Topic   string `json:"topic"`
+				Message struct {
+					Hash    block.Hash        `json:"hash"`
+					Account nano.Address      `json:"account"`
+					Amount  nano.Balance      `json:"amount"`
+					Block   *block.StateBlock `json:"block"`
+				} `json:"message"`
+			}
+			if err := websocket.JSON.Receive(conn, &msg); err != nil {
+				if _, ok := err.(*json.SyntaxError); ok {
+					continue
+				}
+				return
+			}
+			if msg.Topic != "confirmation" {
+				continue
+			}
+
+			event := ConfirmationEvent{
+				Hash:    msg.Message.Hash,
+				Account: msg.Message.Account,
+				Amount:  msg.Message.Amount,
+				Block:   msg.Message.Block,
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+	"littleriver.cc/go-nano/nano"
+)
+
+const testConfirmation = `{
+	"topic": "confirmation",
+	"time": "1564935350664",
+	"message": {
+		"account": "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr",
+		"amount": "1000000000000000000000",
+		"hash": "FF0144381CFF0B2C079A115E7ADA7E96F43FD219446E7524C48D1CC9900C4F17",
+		"confirmation_type": "active_quorum",
+		"block": {
+			"type": "state",
+			"account": "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr",
+			"previous": "F47B23107E5F34B2CE06F562B5C435DF72A533251CB414C51B2B62A8F63A00E4",
+			"representative": "nano_1hza3f7wiiqa7ig3jczyxj5yo86yegcmqk3criaz838j91sxcckpfhbhhra1",
+			"balance": "1000000000000000000000",
+			"link": "19D3D919475DEED4696B5D13018151D1AF88B2BD3BCFF048B45031C1F36D1858",
+			"link_as_account": "nano_18gmu6engqhgtjnppqam181o5nfhj4sdtgyhy36dan3jr9spt84rzwmktafc",
+			"signature": "3BFBA64A775550E6D49DF1EB8EEC2136DCD74F090E2ED658FBD9E80F17CB1C9F9F7BDE2B93D95558EC2F277FFF15FD11E6E2162A1714731B743D1E941FA4560A",
+			"work": "cab7404f0b5449d0",
+			"subtype": "send"
+		}
+	}
+}`
+
+func TestWSClientSubscribeConfirmations(t *testing.T) {
+	subscribed := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		var req map[string]interface{}
+		if err := websocket.JSON.Receive(conn, &req); err != nil {
+			return
+		}
+		subscribed <- req
+
+		websocket.Message.Send(conn, `{"ack": "subscribe", "time": "1564935350000"}`)
+		for i := 0; i < 2; i++ {
+			websocket.Message.Send(conn, testConfirmation)
+		}
+
+		// wait for the client to hang up
+		var msg string
+		websocket.Message.Receive(conn, &msg)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := NewWSClient("ws" + strings.TrimPrefix(server.URL, "http"))
+	account := mustParseAddress(t, "nano_3qgmh14nwztqw4wmcdzy4xpqeejey68chx6nciczwn9abji7ihhum9qtpmdr")
+	events, err := client.SubscribeConfirmations(ctx, []nano.Address{account})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := <-subscribed
+	if req["action"] != "subscribe" || req["topic"] != "confirmation" {
+		t.Fatalf("bad subscribe request: %v", req)
+	}
+	options, ok := req["options"].(map[string]interface{})
+	if !ok || len(options["accounts"].([]interface{})) != 1 {
+		t.Fatalf("bad subscribe options: %v", req["options"])
+	}
+
+	for i := 0; i < 2; i++ {
+		event, ok := <-events
+		if !ok {
+			t.Fatalf("events channel closed early")
+		}
+
+		if event.Hash.String() != "ff0144381cff0b2c079a115e7ada7e96f43fd219446e7524c48d1cc9900c4f17" || event.Block.Hash() != event.Hash {
+			t.Errorf("bad event hash: %s", event.Hash)
+		}
+		if event.Account != account || !event.Amount.Equal(mustParseRaw(t, "1000000000000000000000")) {
+			t.Errorf("bad event: %+v", event)
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
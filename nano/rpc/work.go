@@ -0,0 +1,53 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"littleriver.cc/go-nano/nano/block"
+)
+
+var (
+	ErrWorkNotFound = errors.New("work could not be generated")
+)
+
+// WorkOptions specifies the options for generating work on the node.
+type WorkOptions struct {
+	// Difficulty is the threshold the work has to meet. If zero, the default
+	// threshold of the node is used.
+	Difficulty uint64
+	// Multiplier is the threshold relative to the base threshold of the node. It
+	// is ignored if Difficulty is set.
+	Multiplier float64
+	// UsePeers delegates the generation to the work peers of the node.
+	UsePeers bool
+}
+
+// WorkGenerate requests work for the given root from the node. It returns the
+// work along with its difficulty.
+func (c *Client) WorkGenerate(ctx context.Context, root block.Hash, opts WorkOptions) (block.Work, uint64, error) {
+	var res struct {
+		Work       block.Work `json:"work"`
+		Difficulty string     `json:"difficulty"`
+	}
+	params := map[string]interface{}{"hash": root}
+	if opts.Difficulty != 0 {
+		params["difficulty"] = strconv.FormatUint(opts.Difficulty, 16)
+	} else if opts.Multiplier != 0 {
+		params["multiplier"] = strconv.FormatFloat(opts.Multiplier, 'f', -1, 64)
+	}
+	if opts.UsePeers {
+		params["use_peers"] = "true"
+	}
+	if err := c.Call(ctx, "work_generate", params, &res); err != nil {
+		return 0, 0, err
+	}
+
+	difficulty, err := strconv.ParseUint(res.Difficulty, 16, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return res.Work, difficulty, nil
+}
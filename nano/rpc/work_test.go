@@ -0,0 +1,56 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"littleriver.cc/go-nano/nano/block"
+	"littleriver.cc/go-nano/nano/internal/util"
+)
+
+func TestClientWorkGenerate(t *testing.T) {
+	client, requests := newTestServer(t, map[string]string{
+		"work_generate": `{
+			"work": "2b3d689bbcb21dca",
+			"difficulty": "fffffff93c41ec94",
+			"multiplier": "1.182623871097636",
+			"hash": "718CC2121C3E641059BC1C2CFC45666C99E8AE922F7A807B7D07B62C995D79E2"
+		}`,
+	})
+
+	root := block.Hash(util.MustDecodeHex32("718cc2121c3e641059bc1c2cfc45666c99e8ae922f7a807b7d07b62c995d79e2"))
+	opts := WorkOptions{Difficulty: block.ThresholdSendV2, UsePeers: true}
+	work, difficulty, err := client.WorkGenerate(context.Background(), root, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if work != 0x2b3d689bbcb21dca || difficulty != 0xfffffff93c41ec94 {
+		t.Fatalf("bad work or difficulty: %s, %x", work, difficulty)
+	}
+
+	req := (*requests)[0]
+	if req["hash"] != root.String() || req["difficulty"] != "fffffff800000000" || req["use_peers"] != "true" {
+		t.Fatalf("bad request: %v", req)
+	}
+	if _, ok := req["multiplier"]; ok {
+		t.Fatalf("unexpected multiplier in request: %v", req)
+	}
+
+	if _, _, err = client.WorkGenerate(context.Background(), root, WorkOptions{Multiplier: 2.5}); err != nil {
+		t.Fatal(err)
+	}
+	if req = (*requests)[1]; req["multiplier"] != "2.5" {
+		t.Fatalf("bad multiplier in request: %v", req)
+	}
+}
+
+func TestClientWorkGenerateError(t *testing.T) {
+	client, _ := newTestServer(t, map[string]string{
+		"work_generate": `{"error": "Cannot generate work"}`,
+	})
+
+	if _, _, err := client.WorkGenerate(context.Background(), block.Hash{}, WorkOptions{}); !errors.Is(err, ErrWorkNotFound) {
+		t.Fatalf("expected work not found error, got: %v", err)
+	}
+}
@@ -14,7 +14,7 @@ func generateBlock(t testing.TB) block.Block {
 	random.Bytes(blk.Representative[:])
 	random.Bytes(blk.Address[:])
 	random.Bytes(blk.SourceHash[:])
-	random.Bytes(blk.Signature[:])
+	random.Bytes(blk.Sig[:])
 	return &blk
 }
 
@@ -22,10 +22,10 @@ var (
 			Representative: util.MustDecodeHex32("e89208dd038fbb269987689621d52292ae9c35941a7484756ecced92a65093ba"),
 			Address:        util.MustDecodeHex32("e89208dd038fbb269987689621d52292ae9c35941a7484756ecced92a65093ba"),
 			Work:           0x62f05417dd3fb691,
-			Signature:      util.MustDecodeHex64("9f0c933c8ade004d808ea1985fa746a7e95ba2a38f867640f53ec8f180bdfe9e2c1268dead7c2664f356e37aba362bc58e46dba03e523a7b5a19e4b6eb12bb02"),
+			Sig:            util.MustDecodeHex64("9f0c933c8ade004d808ea1985fa746a7e95ba2a38f867640f53ec8f180bdfe9e2c1268dead7c2664f356e37aba362bc58e46dba03e523a7b5a19e4b6eb12bb02"),
 		},
-		Balance:       nano.ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff),
-		WorkThreshold: uint64(0xffffffc000000000),
+		Balance:       nano.GenesisBalance,
+		WorkThreshold: block.ThresholdBaseV1,
 	}
 
 	Beta = Genesis{
@@ -34,10 +34,10 @@ var (
 			Representative: util.MustDecodeHex32("a59a47cc4f593e75ae9ad653fda9358e2f7898d9acc8c60e80d0495ce20fba9f"),
 			Address:        util.MustDecodeHex32("a59a47cc4f593e75ae9ad653fda9358e2f7898d9acc8c60e80d0495ce20fba9f"),
 			Work:           0x000000000f0aaeeb,
-			Signature:      util.MustDecodeHex64("a726490e3325e4fa59c1c900d5b6eebb15fe13d99f49d475b93f0aacc5635929a0614cf3892764a04d1c6732a0d716ffeb254d4154c6f544d11e6630f201450b"),
+			Sig:            util.MustDecodeHex64("a726490e3325e4fa59c1c900d5b6eebb15fe13d99f49d475b93f0aacc5635929a0614cf3892764a04d1c6732a0d716ffeb254d4154c6f544d11e6630f201450b"),
 		},
-		Balance:       nano.ParseBalanceInts(0xffffffffffffffff, 0xffffffffffffffff),
-		WorkThreshold: uint64(0xffffffc000000000),
+		Balance:       nano.GenesisBalance,
+		WorkThreshold: block.ThresholdBaseV1,
 	}
 )
 
@@ -48,7 +48,7 @@ func (l *Ledger) setGenesis(blk *block.OpenBlock, balance nano.Balance) error {
 	}
 
 	// make sure the signature of this block is valid
-	if !blk.Address.Verify(hash[:], blk.Signature[:]) {
+	if !blk.Address.Verify(hash[:], blk.Sig[:]) {
 		return errors.New("bad signature for genesis block")
 	}
 
@@ -97,7 +97,7 @@ func (l *Ledger) addOpenBlock(txn StoreTxn, blk *block.OpenBlock) error {
 	hash := blk.Hash()
 
 	// make sure the signature of this block is valid
-	if !blk.Address.Verify(hash[:], blk.Signature[:]) {
+	if !blk.Address.Verify(hash[:], blk.Sig[:]) {
 		return errors.New("bad block signature")
 	}
 
@@ -157,7 +157,7 @@ func (l *Ledger) addSendBlock(txn StoreTxn, blk *block.SendBlock) error {
 	}
 
 	// make sure the signature of this block is valid
-	if !frontier.Address.Verify(hash[:], blk.Signature[:]) {
+	if !frontier.Address.Verify(hash[:], blk.Sig[:]) {
 		return errors.New("bad block signature")
 	}
 
@@ -227,7 +227,7 @@ func (l *Ledger) addReceiveBlock(txn StoreTxn, blk *block.ReceiveBlock) error {
 	}
 
 	// make sure the signature of this block is valid
-	if !frontier.Address.Verify(hash[:], blk.Signature[:]) {
+	if !frontier.Address.Verify(hash[:], blk.Sig[:]) {
 		return errors.New("bad block signature")
 	}
 
@@ -293,7 +293,7 @@ func (l *Ledger) addChangeBlock(txn StoreTxn, blk *block.ChangeBlock) error {
 	}
 
 	// make sure the signature of this block is valid
-	if !frontier.Address.Verify(hash[:], blk.Signature[:]) {
+	if !frontier.Address.Verify(hash[:], blk.Sig[:]) {
 		return errors.New("bad block signature")
 	}
 
@@ -347,7 +347,7 @@ func (l *Ledger) addStateBlock(txn StoreTxn, blk *block.StateBlock) error {
 	hash := blk.Hash()
 
 	// make sure the signature of this block is valid
-	if !blk.Address.Verify(hash[:], blk.Signature[:]) {
+	if !blk.Address.Verify(hash[:], blk.Sig[:]) {
 		return errors.New("bad block signature")
 	}
 
@@ -503,8 +503,13 @@ func (l *Ledger) addBlock(txn StoreTxn, blk block.Block) error {
 		return ErrBlockExists
 	}
 
-	// make sure the previous/source block exists
-	found, err = txn.HasBlock(blk.Root())
+	// make sure the previous/source block exists, the root of a state block
+	// opening an account is the account itself
+	dependency := blk.Root()
+	if b, ok := blk.(*block.StateBlock); ok && b.IsOpen() {
+		dependency = b.Link
+	}
+	found, err = txn.HasBlock(dependency)
 	if err != nil {
 		return err
 	}
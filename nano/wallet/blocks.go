@@ -0,0 +1,138 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/block"
+	"littleriver.cc/go-nano/nano/crypto/ed25519"
+	"littleriver.cc/go-nano/nano/rpc"
+)
+
+var (
+	ErrInsufficientBalance = errors.New("amount exceeds the account balance")
+
+	// DefaultRepresentative is the representative of accounts opened with
+	// Receive. If it is the zero address, accounts represent themselves.
+	DefaultRepresentative nano.Address
+)
+
+// Client is the subset of the node RPC interface needed to create and publish
+// blocks. It is implemented by *rpc.Client.
+type Client interface {
+	AccountInfo(ctx context.Context, address nano.Address, opts rpc.AccountInfoOptions) (*rpc.AccountInfo, error)
+	WorkGenerate(ctx context.Context, root block.Hash, opts rpc.WorkOptions) (block.Work, uint64, error)
+	Process(ctx context.Context, blk *block.StateBlock, subtype block.Subtype) (block.Hash, error)
+}
+
+// Send sends the given amount from the account of the given key to another
+// account and returns the hash of the published block. The representative of
+// the account is left unchanged.
+func Send(ctx context.Context, client Client, key ed25519.PrivateKey, to nano.Address, amount nano.Balance) (block.Hash, error) {
+	address, err := nano.AddressFromPublicKey(key.PublicKey())
+	if err != nil {
+		return block.Hash{}, err
+	}
+
+	info, err := client.AccountInfo(ctx, address, rpc.AccountInfoOptions{Representative: true})
+	if err != nil {
+		return block.Hash{}, err
+	}
+
+	balance, err := info.Balance.CheckedSub(amount)
+	if err != nil {
+		return block.Hash{}, ErrInsufficientBalance
+	}
+
+	blk := &block.StateBlock{
+		Address:        address,
+		PreviousHash:   info.Frontier,
+		Representative: info.Representative,
+		Balance:        balance,
+		Link:           block.Hash(to),
+	}
+	return publish(ctx, client, key, blk, block.SubtypeSend)
+}
+
+// Receive receives the given amount sent by the block with the given hash and
+// returns the hash of the published block. If the account has not been opened
+// yet, the block opens it with DefaultRepresentative as its representative.
+func Receive(ctx context.Context, client Client, key ed25519.PrivateKey, hash block.Hash, amount nano.Balance) (block.Hash, error) {
+	address, err := nano.AddressFromPublicKey(key.PublicKey())
+	if err != nil {
+		return block.Hash{}, err
+	}
+
+	blk := &block.StateBlock{
+		Address: address,
+		Link:    hash,
+	}
+
+	opts := rpc.AccountInfoOptions{Representative: true, AllowUnopened: true}
+	info, err := client.AccountInfo(ctx, address, opts)
+	if err != nil {
+		return block.Hash{}, err
+	}
+
+	if info.IsOpen() {
+		if blk.Balance, err = info.Balance.CheckedAdd(amount); err != nil {
+			return block.Hash{}, err
+		}
+		blk.PreviousHash = info.Frontier
+		blk.Representative = info.Representative
+	} else {
+		// the previous hash of the first block of an account is zero
+		blk.Balance = amount
+		blk.Representative = DefaultRepresentative
+		if blk.Representative == (nano.Address{}) {
+			blk.Representative = address
+		}
+	}
+
+	return publish(ctx, client, key, blk, block.SubtypeReceive)
+}
+
+// ChangeRepresentative changes the representative of the account of the given
+// key and returns the hash of the published block. If the account already has
+// the given representative, nothing is published and the hash of its frontier
+// block is returned.
+func ChangeRepresentative(ctx context.Context, client Client, key ed25519.PrivateKey, representative nano.Address) (block.Hash, error) {
+	address, err := nano.AddressFromPublicKey(key.PublicKey())
+	if err != nil {
+		return block.Hash{}, err
+	}
+
+	info, err := client.AccountInfo(ctx, address, rpc.AccountInfoOptions{Representative: true})
+	if err != nil {
+		return block.Hash{}, err
+	}
+
+	if info.Representative == representative {
+		return info.Frontier, nil
+	}
+
+	blk := &block.StateBlock{
+		Address:        address,
+		PreviousHash:   info.Frontier,
+		Representative: representative,
+		Balance:        info.Balance,
+	}
+	return publish(ctx, client, key, blk, block.SubtypeChange)
+}
+
+// publish requests work for the given block, signs it and hands it to the node.
+func publish(ctx context.Context, client Client, key ed25519.PrivateKey, blk *block.StateBlock, subtype block.Subtype) (block.Hash, error) {
+	opts := rpc.WorkOptions{Difficulty: block.ThresholdForSubtype(subtype)}
+	work, _, err := client.WorkGenerate(ctx, blk.Root(), opts)
+	if err != nil {
+		return block.Hash{}, err
+	}
+	blk.Work = work
+
+	if err = blk.Sign(key); err != nil {
+		return block.Hash{}, err
+	}
+
+	return client.Process(ctx, blk, subtype)
+}
@@ -0,0 +1,194 @@
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"littleriver.cc/go-nano/nano"
+	"littleriver.cc/go-nano/nano/block"
+	"littleriver.cc/go-nano/nano/crypto/ed25519"
+	"littleriver.cc/go-nano/nano/internal/util"
+	"littleriver.cc/go-nano/nano/rpc"
+)
+
+// stubClient is a Client that serves a single account and records the blocks
+// that are processed.
+type stubClient struct {
+	info      *rpc.AccountInfo
+	roots     []block.Hash
+	processed []*block.StateBlock
+	subtypes  []block.Subtype
+}
+
+func (c *stubClient) AccountInfo(ctx context.Context, address nano.Address, opts rpc.AccountInfoOptions) (*rpc.AccountInfo, error) {
+	if c.info == nil {
+		if opts.AllowUnopened {
+			return &rpc.AccountInfo{}, nil
+		}
+		return nil, rpc.ErrAccountNotFound
+	}
+	return c.info, nil
+}
+
+func (c *stubClient) WorkGenerate(ctx context.Context, root block.Hash, opts rpc.WorkOptions) (block.Work, uint64, error) {
+	c.roots = append(c.roots, root)
+	return 0x1234, opts.Difficulty, nil
+}
+
+func (c *stubClient) Process(ctx context.Context, blk *block.StateBlock, subtype block.Subtype) (block.Hash, error) {
+	c.processed = append(c.processed, blk)
+	c.subtypes = append(c.subtypes, subtype)
+	return blk.Hash(), nil
+}
+
+var (
+	testFrontier = block.Hash(util.MustDecodeHex32("f47b23107e5f34b2ce06f562b5c435df72a533251cb414c51b2b62a8f63a00e4"))
+	testRep      = nano.Address(util.MustDecodeHex32("e89208dd038fbb269987689621d52292ae9c35941a7484756ecced92a65093ba"))
+)
+
+func testKey(t *testing.T) (ed25519.PrivateKey, nano.Address) {
+	var seed Seed
+	key, err := seed.Key(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, nano.EncodeAddress(key.PublicKey())
+}
+
+func TestWalletSend(t *testing.T) {
+	key, address := testKey(t)
+	client := &stubClient{info: &rpc.AccountInfo{
+		Frontier:       testFrontier,
+		Balance:        nano.ParseBalanceInts(0, 1000),
+		Representative: testRep,
+	}}
+
+	to := nano.Address{1, 2, 3}
+	hash, err := Send(context.Background(), client, key, to, nano.ParseBalanceInts(0, 400))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(client.processed) != 1 || client.subtypes[0] != block.SubtypeSend {
+		t.Fatalf("expected a single send block to be processed")
+	}
+	blk := client.processed[0]
+	if blk.Hash() != hash {
+		t.Errorf("expected hash %s, got: %s", blk.Hash(), hash)
+	}
+	if blk.Address != address || blk.PreviousHash != testFrontier || blk.Representative != testRep {
+		t.Errorf("bad block: %+v", blk)
+	}
+	if !blk.Balance.Equal(nano.ParseBalanceInts(0, 600)) || blk.Link != block.Hash(to) {
+		t.Errorf("bad balance or link: %s, %s", blk.Balance.UnitString("raw", 0), blk.Link)
+	}
+	if client.roots[0] != testFrontier || blk.Work != 0x1234 || !blk.VerifySignature() {
+		t.Errorf("block not worked or signed correctly")
+	}
+}
+
+func TestWalletSendInsufficient(t *testing.T) {
+	key, _ := testKey(t)
+	client := &stubClient{info: &rpc.AccountInfo{
+		Frontier: testFrontier,
+		Balance:  nano.ParseBalanceInts(0, 1000),
+	}}
+
+	_, err := Send(context.Background(), client, key, nano.Address{}, nano.ParseBalanceInts(0, 1001))
+	if err != ErrInsufficientBalance {
+		t.Fatalf("expected insufficient balance error, got: %v", err)
+	}
+	if len(client.roots) != 0 || len(client.processed) != 0 {
+		t.Fatalf("block was published despite the error")
+	}
+}
+
+func TestWalletReceive(t *testing.T) {
+	key, address := testKey(t)
+	client := &stubClient{info: &rpc.AccountInfo{
+		Frontier:       testFrontier,
+		Balance:        nano.ParseBalanceInts(0, 1000),
+		Representative: testRep,
+	}}
+
+	source := block.Hash{4, 5, 6}
+	if _, err := Receive(context.Background(), client, key, source, nano.ParseBalanceInts(0, 500)); err != nil {
+		t.Fatal(err)
+	}
+
+	blk := client.processed[0]
+	if client.subtypes[0] != block.SubtypeReceive || blk.Address != address || blk.Link != source {
+		t.Errorf("bad block: %+v", blk)
+	}
+	if blk.PreviousHash != testFrontier || blk.Representative != testRep || !blk.Balance.Equal(nano.ParseBalanceInts(0, 1500)) {
+		t.Errorf("bad block state: %+v", blk)
+	}
+	if client.roots[0] != testFrontier || !blk.VerifySignature() {
+		t.Errorf("block not worked or signed correctly")
+	}
+
+	client.info.Balance = nano.GenesisBalance
+	if _, err := Receive(context.Background(), client, key, source, nano.ParseBalanceInts(0, 1)); err != nano.ErrBalanceOverflow {
+		t.Errorf("expected overflow error, got: %v", err)
+	}
+}
+
+func TestWalletReceiveOpen(t *testing.T) {
+	key, address := testKey(t)
+	client := &stubClient{}
+
+	source := block.Hash{4, 5, 6}
+	if _, err := Receive(context.Background(), client, key, source, nano.ParseBalanceInts(0, 500)); err != nil {
+		t.Fatal(err)
+	}
+
+	blk := client.processed[0]
+	if !blk.IsOpen() || blk.Link != source || !blk.Balance.Equal(nano.ParseBalanceInts(0, 500)) {
+		t.Errorf("bad open block: %+v", blk)
+	}
+	if blk.Representative != address {
+		t.Errorf("expected the account to represent itself, got: %s", blk.Representative)
+	}
+	if client.roots[0] != block.Hash(address) || !blk.VerifySignature() {
+		t.Errorf("block not worked or signed correctly")
+	}
+
+	DefaultRepresentative = testRep
+	defer func() { DefaultRepresentative = nano.Address{} }()
+	if _, err := Receive(context.Background(), client, key, source, nano.ParseBalanceInts(0, 500)); err != nil {
+		t.Fatal(err)
+	}
+	if blk = client.processed[1]; blk.Representative != testRep {
+		t.Errorf("expected the default representative, got: %s", blk.Representative)
+	}
+}
+
+func TestWalletChangeRepresentative(t *testing.T) {
+	key, address := testKey(t)
+	client := &stubClient{info: &rpc.AccountInfo{
+		Frontier:       testFrontier,
+		Balance:        nano.ParseBalanceInts(0, 1000),
+		Representative: testRep,
+	}}
+
+	// changing to the current representative is a no-op
+	hash, err := ChangeRepresentative(context.Background(), client, key, testRep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != testFrontier || len(client.processed) != 0 {
+		t.Fatalf("expected the frontier without processing, got: %s", hash)
+	}
+
+	if _, err = ChangeRepresentative(context.Background(), client, key, address); err != nil {
+		t.Fatal(err)
+	}
+
+	blk := client.processed[0]
+	if client.subtypes[0] != block.SubtypeChange || blk.Representative != address || !blk.Link.IsZero() {
+		t.Errorf("bad change block: %+v", blk)
+	}
+	if blk.PreviousHash != testFrontier || !blk.Balance.Equal(client.info.Balance) || !blk.VerifySignature() {
+		t.Errorf("bad change block state: %+v", blk)
+	}
+}
@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+
+	"github.com/tyler-smith/go-bip39"
+	"littleriver.cc/go-nano/nano/crypto/ed25519"
+)
+
+const (
+	// bip44CoinType is the BIP44 coin type registered for Nano.
+	bip44CoinType = 165
+
+	hardenedKeyOffset = 0x80000000
+)
+
+// NewMnemonic generates a new BIP39 mnemonic from the given number of bits of
+// entropy. The number of bits should be a multiple of 32 in the range
+// [128, 256]. 256 bits results in a 24 word mnemonic.
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// SeedFromMnemonic returns the BIP39 seed for the given mnemonic and
+// passphrase. An error is returned if one of the words is not in the wordlist
+// or if the checksum of the mnemonic doesn't match.
+func SeedFromMnemonic(mnemonic string, passphrase string) ([]byte, error) {
+	return bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+}
+
+// MnemonicKey derives the private key with the given index from the given BIP39
+// seed, using the BIP44 path m/44'/165'/index'. This is the scheme used by the
+// Ledger Nano app and many other wallets.
+func MnemonicKey(seed []byte, index uint32) (ed25519.PrivateKey, error) {
+	// ed25519 only supports hardened derivation (SLIP-0010)
+	mac := hmac.New(sha512.New, []byte("ed25519 seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	for _, i := range []uint32{44, bip44CoinType, index} {
+		data := make([]byte, 1+len(key)+4)
+		copy(data[1:], key)
+		binary.BigEndian.PutUint32(data[1+len(key):], i+hardenedKeyOffset)
+
+		mac = hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+		key, chainCode = sum[:32], sum[32:]
+	}
+
+	_, privKey, err := ed25519.GenerateKey(bytes.NewReader(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return privKey, nil
+}
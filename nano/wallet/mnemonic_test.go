@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+const testMnemonic = "edge defense waste choose enrich upon flee junk siren film clown finish luggage leader kid quick brick print evidence swap drill paddle truly occur"
+
+func TestWalletMnemonic(t *testing.T) {
+	seed, err := SeedFromMnemonic(testMnemonic, "some password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSeed := "0dc285fde768f7ff29b66ce7252d56ed92fe003b605907f7a4f683c3dc8586d34a914d3c71fc099bb38ee4a59e5b081a3497b7a323e90cc68f67b5837690310c"
+	if s := hex.EncodeToString(seed); s != expectedSeed {
+		t.Fatalf("expected seed: %s, got: %s", expectedSeed, s)
+	}
+
+	key, err := MnemonicKey(seed, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedKey := "3be4fc2ef3f3b7374e6fc4fb6e7bb153f8a2998b3b3dab50853eabe128024143"
+	if s := hex.EncodeToString(key[:32]); s != expectedKey {
+		t.Fatalf("expected key: %s, got: %s", expectedKey, s)
+	}
+
+	expectedAddress := "nano_1pu7p5n3ghq1i1p4rhmek41f5add1uh34xpb94nkbxe8g4a6x1p69emk8y1d"
+	if address := NewAccount(key).Address(); address.String() != expectedAddress {
+		t.Fatalf("expected address: %s, got: %s", expectedAddress, address)
+	}
+}
+
+func TestWalletMnemonicInvalid(t *testing.T) {
+	mnemonic, err := NewMnemonic(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	words := strings.Fields(mnemonic)
+	if len(words) != 24 {
+		t.Fatalf("expected 24 words, got: %d", len(words))
+	}
+
+	if _, err = SeedFromMnemonic(mnemonic, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// replacing a word of a known mnemonic breaks its checksum
+	words = strings.Fields(testMnemonic)
+	words[0] = "echo"
+	if _, err = SeedFromMnemonic(strings.Join(words, " "), ""); err == nil {
+		t.Errorf("expected a checksum error")
+	}
+
+	words[0] = "notaword"
+	if _, err = SeedFromMnemonic(strings.Join(words, " "), ""); err == nil {
+		t.Errorf("expected a wordlist error")
+	}
+
+	if _, err = NewMnemonic(100); err == nil {
+		t.Errorf("expected an error for a bad entropy size")
+	}
+}
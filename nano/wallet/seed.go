@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"errors"
+	"hash"
 
 	"golang.org/x/crypto/blake2b"
+	"littleriver.cc/go-nano/nano"
 	"littleriver.cc/go-nano/nano/crypto/ed25519"
 	"littleriver.cc/go-nano/nano/crypto/random"
 )
@@ -14,8 +17,14 @@ const (
 	SeedSize = 32
 )
 
+var (
+	ErrBadSeedSize   = errors.New("seeds should be 32 bytes in size")
+	ErrIndexOverflow = errors.New("account index range overflows")
+)
+
 type Seed [SeedSize]byte
 
+// GenerateSeed generates a new random seed.
 func GenerateSeed() (*Seed, error) {
 	seed := new(Seed)
 	if err := random.Bytes(seed[:]); err != nil {
@@ -25,27 +34,95 @@ func GenerateSeed() (*Seed, error) {
 	return seed, nil
 }
 
+// ParseSeedHex parses the given hex representation of a seed.
+func ParseSeedHex(s string) (*Seed, error) {
+	if len(s) != hex.EncodedLen(SeedSize) {
+		return nil, ErrBadSeedSize
+	}
+
+	seed := new(Seed)
+	if _, err := hex.Decode(seed[:], []byte(s)); err != nil {
+		return nil, err
+	}
+
+	return seed, nil
+}
+
+// DeriveKey derives the private key with the given index from this seed, as
+// blake2b(seed || index) with the index encoded as a big-endian uint32.
+func (s *Seed) DeriveKey(index uint32) ed25519.PrivateKey {
+	return s.deriveKey(newKeyHash(), index)
+}
+
+// DeriveAccount derives the address with the given index from this seed.
+func (s *Seed) DeriveAccount(index uint32) nano.Address {
+	return NewAccount(s.DeriveKey(index)).Address()
+}
+
+// Key is like DeriveKey. The returned error is always nil.
 func (s *Seed) Key(index uint32) (ed25519.PrivateKey, error) {
-	indexBytes := make([]byte, 4)
-	binary.BigEndian.PutUint32(indexBytes, index)
+	return s.DeriveKey(index), nil
+}
+
+// DeriveAccounts derives the addresses of the given amount of consecutive
+// indexes, starting at start, in ascending order of index. It returns
+// ErrIndexOverflow if the range does not fit in a uint32.
+func (s *Seed) DeriveAccounts(start uint32, count uint32) ([]nano.Address, error) {
+	if count > 0 && start+(count-1) < start {
+		return nil, ErrIndexOverflow
+	}
+
+	hash := newKeyHash()
+	addresses := make([]nano.Address, count)
+	for i := range addresses {
+		addresses[i] = NewAccount(s.deriveKey(hash, start+uint32(i))).Address()
+	}
+
+	return addresses, nil
+}
 
-	hash, err := blake2b.New(blake2b.Size256, nil)
+func newKeyHash() hash.Hash {
+	h, err := blake2b.New(blake2b.Size256, nil)
 	if err != nil {
 		panic(err)
 	}
-	hash.Write(s[:])
-	hash.Write(indexBytes)
+	return h
+}
+
+// deriveKey derives the private key with the given index using the given
+// hash, which is reset first so it can be reused across indexes.
+func (s *Seed) deriveKey(h hash.Hash, index uint32) ed25519.PrivateKey {
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
 
-	sum := hash.Sum(nil)
-	sumReader := bytes.NewReader(sum)
-	_, key, err := ed25519.GenerateKey(sumReader)
+	h.Reset()
+	h.Write(s[:])
+	h.Write(indexBytes[:])
+
+	// reading the 32 byte digest can't fail
+	_, key, err := ed25519.GenerateKey(bytes.NewReader(h.Sum(nil)))
 	if err != nil {
-		return nil, err
+		panic(err)
 	}
 
-	return key, nil
+	return key
 }
 
-func (s *Seed) String() string {
+// Zero overwrites this seed with zeros. Callers should defer it once the seed
+// is no longer needed, so the secret does not linger in memory. Keys derived
+// afterwards are those of the zero seed.
+func (s *Seed) Zero() {
+	for i := range s {
+		s[i] = 0
+	}
+}
+
+// Hex returns the hex representation of this seed.
+func (s *Seed) Hex() string {
 	return hex.EncodeToString(s[:])
 }
+
+// String implements the fmt.Stringer interface. It returns the seed in hex.
+func (s *Seed) String() string {
+	return s.Hex()
+}
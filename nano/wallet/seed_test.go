@@ -0,0 +1,129 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWalletSeedParse(t *testing.T) {
+	seed, err := GenerateSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := ParseSeedHex(seed.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *parsed != *seed {
+		t.Fatalf("expected: %s, got: %s", seed, parsed)
+	}
+
+	s := "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	if parsed, err = ParseSeedHex(strings.ToUpper(s)); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Hex() != s {
+		t.Fatalf("expected: %s, got: %s", s, parsed)
+	}
+
+	for _, s := range []string{"", s[1:], s + "0", s[2:]} {
+		if _, err = ParseSeedHex(s); err != ErrBadSeedSize {
+			t.Errorf("(%s) expected bad seed size error, got: %v", s, err)
+		}
+	}
+
+	if _, err = ParseSeedHex(s[1:] + "g"); err == nil {
+		t.Errorf("expected an error for an invalid hex character")
+	}
+}
+
+func TestWalletSeedKey(t *testing.T) {
+	type keyTest struct {
+		key     string
+		address string
+	}
+
+	seed := new(Seed)
+	tests := []keyTest{
+		{"9f0e444c69f77a49bd0be89db92c38fe713e0963165cca12faf5712d7657120f", "nano_3i1aq1cchnmbn9x5rsbap8b15akfh7wj7pwskuzi7ahz8oq6cobd99d4r3b7"},
+		{"b73b723bf7bd042b66ad3332718ba98de7312f95ed3d05a130c9204552a7afff", "nano_3rrf6cus8pye6o1kzi5n6wwjof8bjb7ff4xcgesi3njxid6x64pms6onw1f9"},
+	}
+
+	for i, test := range tests {
+		key := seed.DeriveKey(uint32(i))
+		if s := hex.EncodeToString(key[:32]); s != test.key {
+			t.Errorf("(%d) expected key: %s, got: %s", i, test.key, s)
+		}
+
+		compat, err := seed.Key(uint32(i))
+		if err != nil || !compat.Equal(key) {
+			t.Errorf("(%d) Key differs from DeriveKey: %x (%v)", i, compat, err)
+		}
+
+		address := seed.DeriveAccount(uint32(i))
+		if address.String() != test.address {
+			t.Errorf("(%d) expected address: %s, got: %s", i, test.address, address)
+		}
+	}
+}
+
+func TestWalletSeedZero(t *testing.T) {
+	seed, err := GenerateSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *seed == (Seed{}) {
+		t.Fatalf("generated the zero seed")
+	}
+
+	seed.Zero()
+	for i, b := range seed {
+		if b != 0 {
+			t.Fatalf("byte %d was not cleared", i)
+		}
+	}
+
+	// a zeroed seed derives the keys of the zero seed
+	key, err := seed.Key(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := hex.EncodeToString(key[:32]); s != "9f0e444c69f77a49bd0be89db92c38fe713e0963165cca12faf5712d7657120f" {
+		t.Errorf("unexpected key: %s", s)
+	}
+}
+
+func TestWalletSeedDeriveAccounts(t *testing.T) {
+	seed, err := GenerateSeed()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const start, count = 5, 20
+	addresses, err := seed.DeriveAccounts(start, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addresses) != count {
+		t.Fatalf("expected %d addresses, got: %d", count, len(addresses))
+	}
+
+	for i, address := range addresses {
+		if expected := seed.DeriveAccount(start + uint32(i)); address != expected {
+			t.Errorf("(%d) expected address: %s, got: %s", start+i, expected, address)
+		}
+	}
+
+	if addresses, err = seed.DeriveAccounts(0, 0); err != nil || len(addresses) != 0 {
+		t.Errorf("expected no addresses, got: %v (%v)", addresses, err)
+	}
+	if addresses, err = seed.DeriveAccounts(math.MaxUint32, 1); err != nil || len(addresses) != 1 {
+		t.Errorf("expected the last address, got: %v (%v)", addresses, err)
+	}
+	if _, err = seed.DeriveAccounts(math.MaxUint32, 2); err != ErrIndexOverflow {
+		t.Errorf("expected index overflow error, got: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+
+	"littleriver.cc/go-nano/nano"
+)
+
+const (
+	// MaxVanityPrefixLen is the length of the longest prefix SearchVanity will
+	// search for. Every extra character makes a search 32 times slower.
+	MaxVanityPrefixLen = 10
+)
+
+var (
+	ErrVanityPrefixLen = fmt.Errorf("vanity prefix should be at most %d characters long", MaxVanityPrefixLen)
+)
+
+// SearchVanity generates random seeds until it finds one of which the first
+// address starts with the given prefix. The prefix is matched against the
+// address directly after the nano_ prefix and the leading '1' or '3' that
+// every address has. The search is spread over the given number of workers,
+// or over all CPUs if it's not positive, and stops when the given context is
+// cancelled.
+func SearchVanity(ctx context.Context, prefix string, workers int) (*Seed, nano.Address, error) {
+	return searchVanity(ctx, prefix, workers, GenerateSeed)
+}
+
+func searchVanity(ctx context.Context, prefix string, workers int, generate func() (*Seed, error)) (*Seed, nano.Address, error) {
+	if len(prefix) > MaxVanityPrefixLen {
+		return nil, nano.Address{}, ErrVanityPrefixLen
+	}
+
+	for _, c := range prefix {
+		if !strings.ContainsRune(nano.AddressEncodingAlphabet, c) {
+			return nil, nano.Address{}, fmt.Errorf("char '%c' is not in nano's encoding alphabet", c)
+		}
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	type result struct {
+		seed    *Seed
+		address nano.Address
+		err     error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// every worker sends at most one result, so none of them can block
+	results := make(chan result, workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for ctx.Err() == nil {
+				seed, err := generate()
+				if err != nil {
+					results <- result{err: err}
+					return
+				}
+
+				key, err := seed.Key(0)
+				if err != nil {
+					results <- result{err: err}
+					return
+				}
+
+				address := NewAccount(key).Address()
+				if strings.HasPrefix(address.String()[len(nano.AddressPrefix)+1:], prefix) {
+					results <- result{seed: seed, address: address}
+					return
+				}
+			}
+		}()
+	}
+
+	select {
+	case res := <-results:
+		if res.err != nil {
+			return nil, nano.Address{}, res.err
+		}
+		return res.seed, res.address, nil
+	case <-ctx.Done():
+		return nil, nano.Address{}, ctx.Err()
+	}
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"sync"
+	"testing"
+
+	"littleriver.cc/go-nano/nano"
+)
+
+func counterSeeds() func() (*Seed, error) {
+	var mutex sync.Mutex
+	var counter uint64
+
+	return func() (*Seed, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		seed := new(Seed)
+		binary.BigEndian.PutUint64(seed[:], counter)
+		counter++
+		return seed, nil
+	}
+}
+
+func TestWalletSearchVanity(t *testing.T) {
+	for _, prefix := range []string{"", "z", "zz"} {
+		seed, address, err := searchVanity(context.Background(), prefix, 2, counterSeeds())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := seed.Key(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if NewAccount(key).Address() != address {
+			t.Fatalf("address does not belong to seed")
+		}
+
+		if !strings.HasPrefix(address.String()[len(nano.AddressPrefix)+1:], prefix) {
+			t.Fatalf("address %s does not have prefix %s", address, prefix)
+		}
+	}
+}
+
+func TestWalletSearchVanityInvalid(t *testing.T) {
+	for _, prefix := range []string{"0", "2", "l", "v", "coffee!"} {
+		if _, _, err := SearchVanity(context.Background(), prefix, 1); err == nil {
+			t.Errorf("(%s) expected an error", prefix)
+		}
+	}
+
+	if _, _, err := SearchVanity(context.Background(), strings.Repeat("1", MaxVanityPrefixLen+1), 1); err != ErrVanityPrefixLen {
+		t.Errorf("expected prefix length error, got: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := SearchVanity(ctx, strings.Repeat("z", MaxVanityPrefixLen), 1); err != context.Canceled {
+		t.Errorf("expected cancellation error, got: %v", err)
+	}
+}